@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/benjamingeer/accicalc/internal/dataset"
+	"github.com/spf13/cobra"
+)
+
+var aggregateCmd *cobra.Command = &cobra.Command{
+	Use:   "aggregate",
+	Short: "Generate a CSV file of numbers of victims of traffic accidents, grouped by various criteria.",
+	Long: `Generate a CSV file of numbers of victims of traffic accidents, grouped by various criteria.
+People who were unharmed are not counted unless --uninjured is used. If no department is
+specified, the whole country is included. --commune can only be used with --department.
+Example:
+
+accicalc aggregate --department 94 --cyclists --pedestrians --by year,category,severity
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		handleError(aggregate)
+	},
+	Args: cobra.NoArgs,
+}
+
+type AggregateOpts struct {
+	PersonneOpts
+	communes         []uint
+	by               []string
+	includeUninjured bool
+}
+
+var aggregateOpts = AggregateOpts{}
+
+var aggregateKeywords = []string{"year", "month", "commune", "category", "severity", "sex", "age"}
+
+func init() {
+	addPersonneFilterFlags(aggregateCmd.Flags(), &aggregateOpts.PersonneOpts)
+	addCsvFlags(aggregateCmd.Flags(), &aggregateOpts.PersonneOpts)
+	aggregateCmd.Flags().UintSliceVarP(&aggregateOpts.communes, "commune", "c", nil, "commune number (can be repeated)")
+	aggregateCmd.Flags().StringSliceVarP(&aggregateOpts.by, "by", "b", nil, fmt.Sprintf("group by: %v", aggregateKeywords))
+	aggregateCmd.Flags().BoolVar(&aggregateOpts.includeUninjured, "uninjured", false, "also count people who were unharmed")
+	rootCmd.AddCommand(aggregateCmd)
+}
+
+type TrancheÂge int
+
+const (
+	TrancheÂgeNonRenseignée TrancheÂge = iota
+	TrancheÂge0À13
+	TrancheÂge14À17
+	TrancheÂge18À24
+	TrancheÂge25À34
+	TrancheÂge35À44
+	TrancheÂge45À54
+	TrancheÂge55À64
+	TrancheÂge65À74
+	TrancheÂge75EtPlus
+)
+
+func (trancheÂge TrancheÂge) String() string {
+	return [...]string{
+		"Non renseigné",
+		"0-13 ans",
+		"14-17 ans",
+		"18-24 ans",
+		"25-34 ans",
+		"35-44 ans",
+		"45-54 ans",
+		"55-64 ans",
+		"65-74 ans",
+		"75 ans et plus",
+	}[trancheÂge]
+}
+
+func (trancheÂge TrancheÂge) MarshalJSON() ([]byte, error) {
+	return json.Marshal(trancheÂge.String())
+}
+
+func getTrancheÂge(usager *dataset.Usager, accident *dataset.Accident) TrancheÂge {
+	if usager.AnnéeNaissance == 0 {
+		return TrancheÂgeNonRenseignée
+	}
+
+	accidentYear, _ := strconv.Atoi(accident.Date[0:4])
+	âge := accidentYear - usager.AnnéeNaissance
+
+	switch {
+	case âge < 14:
+		return TrancheÂge0À13
+	case âge < 18:
+		return TrancheÂge14À17
+	case âge < 25:
+		return TrancheÂge18À24
+	case âge < 35:
+		return TrancheÂge25À34
+	case âge < 45:
+		return TrancheÂge35À44
+	case âge < 55:
+		return TrancheÂge45À54
+	case âge < 65:
+		return TrancheÂge55À64
+	case âge < 75:
+		return TrancheÂge65À74
+	default:
+		return TrancheÂge75EtPlus
+	}
+}
+
+// The number of victims who share the values of the criteria used for grouping.
+// Criteria that aren't used for grouping are left empty.
+type Agrégat struct {
+	Année               int
+	Mois                int
+	Département         string
+	Commune             string
+	CatégorieDePersonne CatégoriePersonne
+	Gravité             dataset.Gravité
+	Sexe                dataset.Sexe
+	Âge                 TrancheÂge
+	NombreDeVictimes    int
+}
+
+func (agrégat Agrégat) AsJson() (string, error) {
+	return dataset.ToJson(agrégat)
+}
+
+// The values of all the criteria for one person. Criteria that aren't used for
+// grouping are left empty.
+type cléAgrégat struct {
+	année               int
+	mois                int
+	département         string
+	commune             string
+	catégorieDePersonne CatégoriePersonne
+	gravité             dataset.Gravité
+	sexe                dataset.Sexe
+	âge                 TrancheÂge
+}
+
+func (clé cléAgrégat) less(other cléAgrégat) bool {
+	if clé.année != other.année {
+		return clé.année < other.année
+	} else if clé.mois != other.mois {
+		return clé.mois < other.mois
+	} else if clé.département != other.département {
+		return clé.département < other.département
+	} else if clé.commune != other.commune {
+		return len(clé.commune) < len(other.commune) ||
+			(len(clé.commune) == len(other.commune) && clé.commune < other.commune)
+	} else if clé.catégorieDePersonne != other.catégorieDePersonne {
+		return clé.catégorieDePersonne < other.catégorieDePersonne
+	} else if clé.gravité != other.gravité {
+		return clé.gravité < other.gravité
+	} else if clé.sexe != other.sexe {
+		return clé.sexe < other.sexe
+	} else {
+		return clé.âge < other.âge
+	}
+}
+
+func aggregate() error {
+	var maybeOutputFile *string
+
+	if aggregateOpts.flags.Changed("out") {
+		maybeOutputFile = &aggregateOpts.outputFile
+	}
+
+	if err := checkCommuneFlags(aggregateOpts.flags); err != nil {
+		return err
+	}
+
+	outputOpts, err := aggregateOpts.outputOpts()
+
+	if err != nil {
+		return err
+	}
+
+	for _, by := range aggregateOpts.by {
+		if !slices.Contains(aggregateKeywords, by) {
+			return fmt.Errorf("invalid value '%v' for --by (expected one of: %v)", by, aggregateKeywords)
+		}
+	}
+
+	groupBy := func(keyword string) bool {
+		return slices.Contains(aggregateOpts.by, keyword)
+	}
+
+	counts := make(map[cléAgrégat]int)
+
+	err = forEachPersonne(&aggregateOpts.PersonneOpts,
+		func(accident *dataset.Accident) bool {
+			return len(aggregateOpts.communes) == 0 ||
+				(accident.Commune != nil && slices.Contains(aggregateOpts.communes, uint(*accident.Commune)))
+		},
+		func(accident *dataset.Accident, véhicule *dataset.Véhicule, usager *dataset.Usager) {
+			if !aggregateOpts.includeUninjured && !isVictim(usager) {
+				return
+			}
+
+			var clé cléAgrégat
+
+			if groupBy("year") {
+				clé.année, _ = strconv.Atoi(accident.Date[0:4])
+			}
+
+			if groupBy("month") {
+				clé.mois, _ = strconv.Atoi(accident.Date[5:7])
+			}
+
+			if groupBy("commune") {
+				clé.département = accident.Département
+				clé.commune = formatCommune(accident.Commune)
+			}
+
+			if groupBy("category") {
+				clé.catégorieDePersonne = getCatégoriePersonne(usager, véhicule)
+			}
+
+			if groupBy("severity") {
+				clé.gravité = usager.Gravité
+			}
+
+			if groupBy("sex") {
+				clé.sexe = usager.Sexe
+			}
+
+			if groupBy("age") {
+				clé.âge = getTrancheÂge(usager, accident)
+			}
+
+			counts[clé]++
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	var clés []cléAgrégat
+
+	for clé := range counts {
+		clés = append(clés, clé)
+	}
+
+	sort.Slice(clés, func(left, right int) bool {
+		return clés[left].less(clés[right])
+	})
+
+	var agrégats []Agrégat
+
+	for _, clé := range clés {
+		agrégats = append(agrégats, Agrégat{
+			Année:               clé.année,
+			Mois:                clé.mois,
+			Département:         clé.département,
+			Commune:             clé.commune,
+			CatégorieDePersonne: clé.catégorieDePersonne,
+			Gravité:             clé.gravité,
+			Sexe:                clé.sexe,
+			Âge:                 clé.âge,
+			NombreDeVictimes:    counts[clé],
+		})
+	}
+
+	return dataset.WriteCsv(dataset.NewTableau(colonnesAgrégat(groupBy), agrégats), maybeOutputFile, outputOpts)
+}
+
+// Returns the columns of a table of aggregates: one for each criterion used for
+// grouping, and the number of victims.
+func colonnesAgrégat(groupBy func(keyword string) bool) []dataset.Colonne {
+	var colonnes []dataset.Colonne
+
+	if groupBy("year") {
+		colonnes = append(colonnes, dataset.ColonneEntier("Année", func(agrégat Agrégat) int { return agrégat.Année }))
+	}
+
+	if groupBy("month") {
+		colonnes = append(colonnes, dataset.ColonneEntier("Mois", func(agrégat Agrégat) int { return agrégat.Mois }))
+	}
+
+	if groupBy("commune") {
+		colonnes = append(colonnes,
+			dataset.ColonneTexte("Département", func(agrégat Agrégat) string { return agrégat.Département }),
+			dataset.ColonneTexte("Commune", func(agrégat Agrégat) string { return agrégat.Commune }),
+		)
+	}
+
+	if groupBy("category") {
+		colonnes = append(colonnes, dataset.ColonneCatégorie("Catégorie de personne", func(agrégat Agrégat) CatégoriePersonne {
+			return agrégat.CatégorieDePersonne
+		}))
+	}
+
+	if groupBy("severity") {
+		colonnes = append(colonnes, dataset.ColonneCatégorie("Gravité", func(agrégat Agrégat) dataset.Gravité { return agrégat.Gravité }))
+	}
+
+	if groupBy("sex") {
+		colonnes = append(colonnes, dataset.ColonneCatégorie("Sexe", func(agrégat Agrégat) dataset.Sexe { return agrégat.Sexe }))
+	}
+
+	if groupBy("age") {
+		colonnes = append(colonnes, dataset.ColonneCatégorie("Âge", func(agrégat Agrégat) TrancheÂge { return agrégat.Âge }))
+	}
+
+	return append(colonnes,
+		dataset.ColonneEntier("Nombre de victimes", func(agrégat Agrégat) int { return agrégat.NombreDeVictimes }),
+	)
+}
+
+// Returns true if a person was killed or injured.
+func isVictim(usager *dataset.Usager) bool {
+	return usager.Gravité == dataset.Tué ||
+		usager.Gravité == dataset.BlesséHospitalisé ||
+		usager.Gravité == dataset.BlesséLéger
+}
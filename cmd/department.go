@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/benjamingeer/accicalc/internal/dataset"
+	"github.com/spf13/cobra"
+)
+
+var departmentCmd *cobra.Command = &cobra.Command{
+	Use:   "department",
+	Short: "Generate a CSV file of people involved in traffic accidents in a particular department.",
+	Long: `Generate a CSV file of people involved in traffic accidents in a particular department.
+Each row includes the number of the commune where the accident took place.
+Example:
+
+accicalc department --department 94 --cyclists
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		handleError(departmentPersonnes)
+	},
+	Args: cobra.NoArgs,
+}
+
+var departmentOpts = PersonneOpts{}
+
+func init() {
+	addPersonneFlags(departmentCmd.Flags(), &departmentOpts)
+	_ = departmentCmd.MarkFlagRequired("department")
+	rootCmd.AddCommand(departmentCmd)
+}
+
+func departmentPersonnes() error {
+	return writePersonnes(&departmentOpts, func(accident *dataset.Accident) bool {
+		return true
+	})
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/benjamingeer/accicalc/internal/dataset"
+	"github.com/spf13/cobra"
+)
+
+var dumpCmd *cobra.Command = &cobra.Command{
+	Use:   "dump",
+	Short: "Write complete accidents as JSON Lines.",
+	Long: `Write complete accidents as JSON Lines, i.e. one JSON object per line for each accident,
+including its place, vehicles and users. If no department is specified, the whole country is included.
+--commune can only be used with --department.
+Example:
+
+accicalc dump --department 94 --commune 33,41 --out accidents.jsonl
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		handleError(dump)
+	},
+	Args: cobra.NoArgs,
+}
+
+var dumpOpts = ExportOpts{}
+
+func init() {
+	dumpCmd.Flags().StringVarP(&dumpOpts.département, "department", "p", "", "department code")
+	dumpCmd.Flags().UintSliceVarP(&dumpOpts.communes, "commune", "c", nil, "commune number (can be repeated)")
+	dumpCmd.Flags().StringVarP(&dumpOpts.outputFile, "out", "o", "", "output file (defaults to standard out)")
+	dumpOpts.flags = dumpCmd.Flags()
+	rootCmd.AddCommand(dumpCmd)
+}
+
+func dump() error {
+	var maybeOutputFile *string
+
+	if dumpOpts.flags.Changed("out") {
+		maybeOutputFile = &dumpOpts.outputFile
+	}
+
+	accidents, err := readExportedAccidents(&dumpOpts)
+
+	if err != nil {
+		return err
+	}
+
+	return dataset.WriteJsonLines(accidents, maybeOutputFile)
+}
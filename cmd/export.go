@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"slices"
+
+	"github.com/benjamingeer/accicalc/internal/dataset"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var exportCmd *cobra.Command = &cobra.Command{
+	Use:   "export",
+	Short: "Export the complete accident data in other formats.",
+	Long:  `Export the complete accident data in other formats.`,
+}
+
+// Options for commands that write complete accidents.
+type ExportOpts struct {
+	flags       *pflag.FlagSet
+	département string
+	communes    []uint
+	outputFile  string
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+}
+
+// Reads the accidents in the department and communes specified in exportOpts,
+// if any.
+func readExportedAccidents(exportOpts *ExportOpts) ([]*dataset.Accident, error) {
+	if err := checkCommuneFlags(exportOpts.flags); err != nil {
+		return nil, err
+	}
+
+	accidents, err := readAccidents()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dataset.Filter(accidents, func(accident *dataset.Accident) bool {
+		return (exportOpts.département == "" || accident.Département == exportOpts.département) &&
+			(len(exportOpts.communes) == 0 ||
+				(accident.Commune != nil && slices.Contains(exportOpts.communes, uint(*accident.Commune))))
+	}), nil
+}
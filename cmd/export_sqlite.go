@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/benjamingeer/accicalc/internal/dataset"
+	"github.com/spf13/cobra"
+)
+
+var exportSqliteCmd *cobra.Command = &cobra.Command{
+	Use:   "sqlite",
+	Short: "Write accidents to a SQLite database.",
+	Long: `Write accidents to a new SQLite database, in the tables accidents, lieux, vehicules and usagers.
+The table nomenclature contains the labels of the official codes. If no department is specified,
+the whole country is included. --commune can only be used with --department.
+Example:
+
+accicalc export sqlite --department 94 --out accidents.db
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		handleError(exportSqlite)
+	},
+	Args: cobra.NoArgs,
+}
+
+var exportSqliteOpts = ExportOpts{}
+
+func init() {
+	exportSqliteCmd.Flags().StringVarP(&exportSqliteOpts.département, "department", "p", "", "department code")
+	exportSqliteCmd.Flags().UintSliceVarP(&exportSqliteOpts.communes, "commune", "c", nil, "commune number (can be repeated)")
+	exportSqliteCmd.Flags().StringVarP(&exportSqliteOpts.outputFile, "out", "o", "", "output file")
+	_ = exportSqliteCmd.MarkFlagRequired("out")
+	exportSqliteOpts.flags = exportSqliteCmd.Flags()
+	exportCmd.AddCommand(exportSqliteCmd)
+}
+
+func exportSqlite() error {
+	accidents, err := readExportedAccidents(&exportSqliteOpts)
+
+	if err != nil {
+		return err
+	}
+
+	return dataset.WriteSqlite(accidents, exportSqliteOpts.outputFile)
+}
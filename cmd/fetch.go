@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/benjamingeer/accicalc/internal/datagouv"
+	"github.com/benjamingeer/accicalc/internal/dataset"
+	"github.com/spf13/cobra"
+)
+
+var fetchCmd *cobra.Command = &cobra.Command{
+	Use:   "fetch",
+	Short: "Download the accident data files from data.gouv.fr.",
+	Long: `Download the accident data files for the chosen years from data.gouv.fr, using the
+dataset catalogue API, and save them under the data directory with the names that the
+other commands expect. Files that already exist are skipped unless --force is used.
+The size and checksum of each downloaded file are recorded in a manifest, which the
+verify command uses.
+Example:
+
+accicalc fetch --startYear 2019 --endYear 2023
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		handleError(fetch)
+	},
+	Args: cobra.NoArgs,
+}
+
+type FetchOpts struct {
+	baseUrl   string
+	datasetId string
+	force     bool
+}
+
+var fetchOpts = FetchOpts{}
+
+func init() {
+	fetchCmd.Flags().StringVar(&fetchOpts.baseUrl, "base-url", datagouv.DefaultBaseUrl, "base URL of the catalogue API")
+	fetchCmd.Flags().StringVar(&fetchOpts.datasetId, "dataset", datagouv.DefaultDatasetId, "identifier of the dataset in the catalogue")
+	fetchCmd.Flags().BoolVar(&fetchOpts.force, "force", false, "download files that already exist")
+	rootCmd.AddCommand(fetchCmd)
+}
+
+func fetch() error {
+	if err := checkYears(); err != nil {
+		return err
+	}
+
+	client := datagouv.NewClient(fetchOpts.baseUrl)
+	fmt.Fprintf(os.Stderr, "Reading catalogue %v...\n", client.BaseUrl)
+	jeuDeDonnées, err := client.GetJeuDeDonnées(fetchOpts.datasetId)
+
+	if err != nil {
+		return err
+	}
+
+	ressources, doublons := jeuDeDonnées.ResourcesByFile()
+
+	for _, doublon := range doublons {
+		fmt.Fprintln(os.Stderr, doublon)
+	}
+
+	manifeste, err := dataset.ReadOrCreateManifest(opts.dataPath)
+
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+
+	for year := opts.startYear; year <= opts.endYear; year++ {
+		for _, fichier := range dataset.Fichiers {
+			ressource, ok := ressources[datagouv.CléFichier{Year: year, Fichier: fichier}]
+
+			if !ok {
+				missing = append(missing, dataset.FileName(fichier, year))
+				continue
+			}
+
+			destPath := dataset.FilePath(opts.dataPath, fichier, year)
+
+			if _, err := os.Stat(destPath); err == nil && !fetchOpts.force {
+				fmt.Fprintf(os.Stderr, "Skipping %v, which already exists\n", destPath)
+				continue
+			}
+
+			fmt.Fprintf(os.Stderr, "Downloading %v...\n", destPath)
+
+			if _, err := client.Download(ressource, destPath); err != nil {
+				return err
+			}
+
+			if err := addToManifest(manifeste, fichier, year, ressource.Url); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("not found in the catalogue: %v", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
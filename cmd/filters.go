@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/benjamingeer/accicalc/internal/dataset"
+	"github.com/spf13/pflag"
+	"golang.org/x/exp/maps"
+)
+
+// Returns an error if --commune is used without --department, since commune
+// numbers are only unique within a department.
+func checkCommuneFlags(flags *pflag.FlagSet) error {
+	if flags.Changed("commune") && !flags.Changed("department") {
+		return errors.New("--commune requires --department")
+	}
+
+	return nil
+}
+
+// Keywords that can be given to filter options, each of which selects one or more values.
+
+var luminositéKeywords = map[string][]dataset.Luminosité{
+	"day":   {dataset.PleinJour},
+	"dusk":  {dataset.CrépusculeOuAube},
+	"night": {dataset.NuitSansÉclairagePublic, dataset.NuitAvecÉclairagePublicNonAllumé, dataset.NuitAvecÉclairagePublicAllumé},
+	"lit":   {dataset.NuitAvecÉclairagePublicAllumé},
+	"unlit": {dataset.NuitSansÉclairagePublic, dataset.NuitAvecÉclairagePublicNonAllumé},
+}
+
+var agglomérationKeywords = map[string][]dataset.Agglomération{
+	"urban": {dataset.EnAgglomération},
+	"rural": {dataset.HorsAgglomération},
+}
+
+var intersectionKeywords = map[string][]dataset.Intersection{
+	"none": {dataset.HorsIntersection},
+	"any": {
+		dataset.IntersectionEnX,
+		dataset.IntersectionEnT,
+		dataset.IntersectionEnY,
+		dataset.IntersectionÀPlusDe4Branches,
+		dataset.IntersectionGiratoire,
+		dataset.IntersectionPlace,
+		dataset.IntersectionPassageÀNiveau,
+		dataset.AutreIntersection,
+	},
+	"x":              {dataset.IntersectionEnX},
+	"t":              {dataset.IntersectionEnT},
+	"y":              {dataset.IntersectionEnY},
+	"multi":          {dataset.IntersectionÀPlusDe4Branches},
+	"roundabout":     {dataset.IntersectionGiratoire},
+	"square":         {dataset.IntersectionPlace},
+	"level-crossing": {dataset.IntersectionPassageÀNiveau},
+	"other":          {dataset.AutreIntersection},
+}
+
+var conditionsAtmosphériquesKeywords = map[string][]dataset.ConditionsAtmosphériques{
+	"normal":     {dataset.ConditionsAtmosphériquesNormales},
+	"rain":       {dataset.PluieLégère, dataset.PluieForte},
+	"light-rain": {dataset.PluieLégère},
+	"heavy-rain": {dataset.PluieForte},
+	"snow":       {dataset.NeigeGrêle},
+	"fog":        {dataset.BrouillardFumée},
+	"wind":       {dataset.VentFortTempête},
+	"glare":      {dataset.TempsÉblouissant},
+	"overcast":   {dataset.TempsCouvert},
+	"other":      {dataset.AutresConditionsAtmosphériques},
+}
+
+var typeCollisionKeywords = map[string][]dataset.TypeCollision{
+	"front":    {dataset.DeuxVéhiculesFrontale},
+	"rear":     {dataset.DeuxVéhiculesParLArrière},
+	"side":     {dataset.DeuxVéhiculesParLeCôté},
+	"chain":    {dataset.TroisVéhiculesEtPlusEnChaîne},
+	"multiple": {dataset.TroisVéhiculesEtPlusCollisionsMultiples},
+	"other":    {dataset.AutreCollision},
+	"none":     {dataset.SansCollision},
+}
+
+var catégorieRouteKeywords = map[string][]dataset.CatégorieRoute{
+	"motorway":     {dataset.Autoroute},
+	"national":     {dataset.RouteNationale},
+	"departmental": {dataset.RouteDépartementale},
+	"communal":     {dataset.VoieCommunale},
+	"off-network":  {dataset.HorsRéseauPublic},
+	"parking":      {dataset.ParcDeStationnement},
+	"metropolitan": {dataset.RouteDeMétropoleUrbaine},
+	"other":        {dataset.AutreRoute},
+}
+
+var étatSurfaceKeywords = map[string][]dataset.ÉtatSurface{
+	"normal":  {dataset.SurfaceNormale},
+	"wet":     {dataset.Mouillée},
+	"puddles": {dataset.Flaques},
+	"flooded": {dataset.Inondée},
+	"snow":    {dataset.Enneigée},
+	"mud":     {dataset.Boue},
+	"ice":     {dataset.Verglacée},
+	"oil":     {dataset.CorpsGras},
+	"other":   {dataset.AutreÉtatSurface},
+}
+
+var motifTrajetKeywords = map[string][]dataset.MotifTrajet{
+	"work":         {dataset.DomicileTravail},
+	"school":       {dataset.DomicileÉcole},
+	"shopping":     {dataset.CoursesAchats},
+	"professional": {dataset.UtilisationProfessionnelle},
+	"leisure":      {dataset.PromenadeLoisirs},
+	"other":        {dataset.AutreTrajet},
+}
+
+// A set of values selected by a filter option. An empty set means that the option
+// was not used, so every value is accepted.
+type selection[T comparable] map[T]bool
+
+func (sel selection[T]) accepts(value T) bool {
+	return len(sel) == 0 || sel[value]
+}
+
+func parseKeywords[T comparable](flagName string, keywordArgs []string, keywords map[string][]T) (selection[T], error) {
+	sel := make(selection[T])
+
+	for _, keywordArg := range keywordArgs {
+		if values, ok := keywords[strings.ToLower(keywordArg)]; ok {
+			for _, value := range values {
+				sel[value] = true
+			}
+		} else {
+			return nil, fmt.Errorf(
+				"invalid value '%v' for --%v (expected one of: %v)",
+				keywordArg,
+				flagName,
+				keywordList(keywords),
+			)
+		}
+	}
+
+	return sel, nil
+}
+
+func keywordList[T any](keywords map[string][]T) string {
+	keys := maps.Keys(keywords)
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
+
+func keywordUsage[T any](description string, keywords map[string][]T) string {
+	return fmt.Sprintf("%v: %v", description, keywordList(keywords))
+}
+
+// Returns a function that selects the accidents matching the filter options.
+func accidentFilter(personneOpts *PersonneOpts) (func(accident *dataset.Accident) bool, error) {
+	luminosités, err := parseKeywords("lighting", personneOpts.lighting, luminositéKeywords)
+
+	if err != nil {
+		return nil, err
+	}
+
+	agglomérations, err := parseKeywords("area", personneOpts.area, agglomérationKeywords)
+
+	if err != nil {
+		return nil, err
+	}
+
+	intersections, err := parseKeywords("intersection", personneOpts.intersection, intersectionKeywords)
+
+	if err != nil {
+		return nil, err
+	}
+
+	conditionsAtmosphériques, err := parseKeywords("weather", personneOpts.weather, conditionsAtmosphériquesKeywords)
+
+	if err != nil {
+		return nil, err
+	}
+
+	typesCollision, err := parseKeywords("collision", personneOpts.collision, typeCollisionKeywords)
+
+	if err != nil {
+		return nil, err
+	}
+
+	catégoriesRoute, err := parseKeywords("road-category", personneOpts.roadCategory, catégorieRouteKeywords)
+
+	if err != nil {
+		return nil, err
+	}
+
+	étatsSurface, err := parseKeywords("surface", personneOpts.surface, étatSurfaceKeywords)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vitessesMaximales := make(selection[dataset.Nombre])
+
+	for _, speedLimit := range personneOpts.speedLimit {
+		vitessesMaximales[dataset.Nombre(speedLimit)] = true
+	}
+
+	return func(accident *dataset.Accident) bool {
+		lieu := getLieu(accident)
+
+		return luminosités.accepts(accident.Luminosité) &&
+			agglomérations.accepts(accident.Agglomération) &&
+			intersections.accepts(accident.Intersection) &&
+			conditionsAtmosphériques.accepts(accident.ConditionsAtmosphériques) &&
+			typesCollision.accepts(accident.TypeCollision) &&
+			catégoriesRoute.accepts(lieu.CatégorieRoute) &&
+			étatsSurface.accepts(lieu.ÉtatSurface) &&
+			vitessesMaximales.accepts(lieu.VitesseMaximale)
+	}, nil
+}
+
+// Returns a function that selects the users matching the filter options.
+func usagerFilter(personneOpts *PersonneOpts) (func(usager *dataset.Usager, accident *dataset.Accident) bool, error) {
+	if personneOpts.limitToHelmet && personneOpts.limitToNoHelmet {
+		return nil, errors.New("--helmet and --no-helmet cannot be used together")
+	}
+
+	motifsTrajet, err := parseKeywords("trip-purpose", personneOpts.tripPurpose, motifTrajetKeywords)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func(usager *dataset.Usager, accident *dataset.Accident) bool {
+		return (!personneOpts.limitToMinors || wasMinor(usager, accident)) &&
+			(!personneOpts.limitToHelmet || usager.Équipements.Casque == dataset.Utilisé) &&
+			(!personneOpts.limitToNoHelmet || usager.Équipements.Casque == dataset.NonUtilisé) &&
+			motifsTrajet.accepts(usager.MotifTrajet)
+	}, nil
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/benjamingeer/accicalc/internal/dataset"
+)
+
+var outputFormats = []string{"csv", "geojson", "kml", "gpx", "parquet", "xlsx"}
+
+// Writes a table in the format chosen with --format.
+func writeRows(tableau dataset.Tableau, maybeOutputFile *string, format string, outputOpts dataset.OutputOpts) error {
+	switch format {
+	case "csv":
+		return dataset.WriteCsv(tableau, maybeOutputFile, outputOpts)
+	case "geojson":
+		return reportSkipped(dataset.WriteGeoJson(tableau, maybeOutputFile, outputOpts))
+	case "kml":
+		return reportSkipped(dataset.WriteKml(tableau, maybeOutputFile, outputOpts))
+	case "gpx":
+		return reportSkipped(dataset.WriteGpx(tableau, maybeOutputFile, outputOpts))
+	case "parquet":
+		return dataset.WriteParquet(tableau, maybeOutputFile, outputOpts)
+	case "xlsx":
+		return dataset.WriteXlsx(tableau, maybeOutputFile, outputOpts)
+	default:
+		return checkOutputFormat(format)
+	}
+}
+
+func checkOutputFormat(format string) error {
+	if !slices.Contains(outputFormats, format) {
+		return fmt.Errorf("invalid output format '%v' (expected one of: %v)", format, outputFormats)
+	}
+
+	return nil
+}
+
+// Reports the number of rows that a map format writer skipped because they had
+// no coordinates.
+func reportSkipped(skipped int, err error) error {
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %v rows without coordinates\n", skipped)
+	}
+
+	return err
+}
@@ -0,0 +1,621 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/benjamingeer/accicalc/internal/dataset"
+	"github.com/spf13/pflag"
+)
+
+// Options shared by the commands that produce tables of people.
+type PersonneOpts struct {
+	flags                   *pflag.FlagSet
+	département             string
+	includePedestrians      bool
+	includeCyclists         bool
+	includeEDP              bool
+	includeOthersInVehicles bool
+	limitToMinors           bool
+	lighting                []string
+	area                    []string
+	intersection            []string
+	weather                 []string
+	collision               []string
+	includeConditions       bool
+	roadCategory            []string
+	surface                 []string
+	speedLimit              []uint
+	includeRoad             bool
+	limitToHelmet           bool
+	limitToNoHelmet         bool
+	includeEquipment        bool
+	tripPurpose             []string
+	includeTrip             bool
+	rawCodes                bool
+	format                  string
+	delimiter               string
+	bom                     bool
+	decimalSeparator        string
+	columns                 []string
+	outputFile              string
+}
+
+// Adds the flags for selecting people and for choosing the columns of the output.
+func addPersonneFlags(flags *pflag.FlagSet, personneOpts *PersonneOpts) {
+	addPersonneFilterFlags(flags, personneOpts)
+	flags.BoolVar(&personneOpts.includeConditions, "conditions", false, "include columns describing the conditions of each accident")
+	flags.BoolVar(&personneOpts.includeRoad, "road", false, "include columns describing the road where each accident took place")
+	flags.BoolVar(&personneOpts.includeEquipment, "equipment", false, "include columns describing the safety equipment used by each person")
+	flags.BoolVar(&personneOpts.includeTrip, "trip", false, "include columns describing the trip and seat position of each person")
+	flags.BoolVar(&personneOpts.rawCodes, "raw-codes", false, "write official codes and labels instead of simplified categories")
+	flags.StringVarP(&personneOpts.format, "format", "f", "csv", fmt.Sprintf("output format: %v", outputFormats))
+	addCsvFlags(flags, personneOpts)
+}
+
+// Adds the flags that control the layout of CSV files.
+func addCsvFlags(flags *pflag.FlagSet, personneOpts *PersonneOpts) {
+	flags.StringVar(&personneOpts.delimiter, "delimiter", ",", "CSV field delimiter: ',', ';' or 'tab'")
+	flags.BoolVar(&personneOpts.bom, "bom", false, "start CSV files with a UTF-8 byte order mark, for Excel")
+	flags.StringVar(&personneOpts.decimalSeparator, "decimal-separator", ",", "decimal separator in coordinates: ',' or '.'")
+	flags.StringSliceVar(&personneOpts.columns, "columns", nil, "names or headings of the columns to write, in order (defaults to all columns)")
+}
+
+// Returns the options for writing rows, as specified by the flags.
+func (personneOpts *PersonneOpts) outputOpts() (dataset.OutputOpts, error) {
+	outputOpts := dataset.OutputOpts{
+		RawCodes: personneOpts.rawCodes,
+		Bom:      personneOpts.bom,
+		Columns:  personneOpts.columns,
+	}
+
+	delimiter, err := parseDelimiter(personneOpts.delimiter)
+
+	if err != nil {
+		return outputOpts, err
+	}
+
+	outputOpts.Delimiter = delimiter
+
+	switch personneOpts.decimalSeparator {
+	case ",":
+		outputOpts.DecimalPoint = false
+	case ".":
+		outputOpts.DecimalPoint = true
+	default:
+		return outputOpts, fmt.Errorf("invalid decimal separator '%v'", personneOpts.decimalSeparator)
+	}
+
+	return outputOpts, nil
+}
+
+func parseDelimiter(delimiter string) (rune, error) {
+	switch delimiter {
+	case ",":
+		return ',', nil
+	case ";":
+		return ';', nil
+	case "tab", "\\t":
+		return '\t', nil
+	default:
+		return 0, fmt.Errorf("invalid delimiter '%v'", delimiter)
+	}
+}
+
+// Adds the flags for selecting people, and the output file flag.
+func addPersonneFilterFlags(flags *pflag.FlagSet, personneOpts *PersonneOpts) {
+	flags.StringVarP(&personneOpts.département, "department", "p", "", "department code")
+	flags.BoolVarP(&personneOpts.includePedestrians, "pedestrians", "r", false, "include pedestrians")
+	flags.BoolVarP(&personneOpts.includeCyclists, "cyclists", "y", false, "include cyclists")
+	flags.BoolVar(&personneOpts.includeEDP, "edp", false, "include users of personal mobility devices (e-scooters, etc.)")
+	flags.BoolVarP(&personneOpts.includeOthersInVehicles, "other", "t", false, "include other vehicle drivers/passengers")
+	flags.BoolVarP(&personneOpts.limitToMinors, "minors", "m", false, "minors only")
+	flags.StringSliceVar(&personneOpts.lighting, "lighting", nil, keywordUsage("lighting", luminositéKeywords))
+	flags.StringSliceVar(&personneOpts.area, "area", nil, keywordUsage("urban or rural area", agglomérationKeywords))
+	flags.StringSliceVar(&personneOpts.intersection, "intersection", nil, keywordUsage("type of intersection", intersectionKeywords))
+	flags.StringSliceVar(&personneOpts.weather, "weather", nil, keywordUsage("weather", conditionsAtmosphériquesKeywords))
+	flags.StringSliceVar(&personneOpts.collision, "collision", nil, keywordUsage("type of collision", typeCollisionKeywords))
+	flags.StringSliceVar(&personneOpts.roadCategory, "road-category", nil, keywordUsage("road category", catégorieRouteKeywords))
+	flags.StringSliceVar(&personneOpts.surface, "surface", nil, keywordUsage("road surface", étatSurfaceKeywords))
+	flags.UintSliceVar(&personneOpts.speedLimit, "speed-limit", nil, "speed limit in km/h (only available from 2019)")
+	flags.BoolVar(&personneOpts.limitToHelmet, "helmet", false, "only people who were wearing a helmet")
+	flags.BoolVar(&personneOpts.limitToNoHelmet, "no-helmet", false, "only people who were not wearing a helmet")
+	flags.StringSliceVar(&personneOpts.tripPurpose, "trip-purpose", nil, keywordUsage("purpose of the trip", motifTrajetKeywords))
+	flags.StringVarP(&personneOpts.outputFile, "out", "o", "", "output file (defaults to standard out)")
+	personneOpts.flags = flags
+}
+
+type CatégoriePersonne int
+
+const (
+	CatégoriePersonnePiéton CatégoriePersonne = iota
+	CatégoriePersonneCycliste
+	CatégoriePersonneEDP
+	CatégoriePersonneAutre
+)
+
+func (catégoriePersonne CatégoriePersonne) String() string {
+	return [...]string{
+		"Piéton",
+		"Cycliste",
+		"Engin de déplacement personnel",
+		"Autre",
+	}[catégoriePersonne]
+}
+
+func (catégoriePersonne CatégoriePersonne) MarshalJSON() ([]byte, error) {
+	return json.Marshal(catégoriePersonne.String())
+}
+
+func getCatégoriePersonne(usager *dataset.Usager, véhicule *dataset.Véhicule) CatégoriePersonne {
+	if usager.CatégorieUsager == dataset.Piéton {
+		return CatégoriePersonnePiéton
+	} else if usager.CatégorieUsager == dataset.PiétonEnRollerOuTrottinette {
+		return CatégoriePersonneEDP
+	} else if usager.CatégorieUsager == dataset.Conducteur && véhicule != nil {
+		switch véhicule.CatégorieVéhicule {
+		case dataset.Bicyclette, dataset.VéloÀAssistanceÉlectrique:
+			return CatégoriePersonneCycliste
+
+		case dataset.EDPÀMoteur, dataset.EDPSansMoteur, dataset.EDPNonPrécisé:
+			return CatégoriePersonneEDP
+		}
+	}
+
+	return CatégoriePersonneAutre
+}
+
+// The conditions of an accident.
+type ConditionsAccident struct {
+	Luminosité               dataset.Codé[dataset.Luminosité]
+	Agglomération            dataset.Codé[dataset.Agglomération]
+	Intersection             dataset.Codé[dataset.Intersection]
+	ConditionsAtmosphériques dataset.Codé[dataset.ConditionsAtmosphériques]
+	TypeDeCollision          dataset.Codé[dataset.TypeCollision]
+}
+
+// The road where an accident took place.
+type Route struct {
+	CatégorieDeRoute    dataset.Codé[dataset.CatégorieRoute]
+	RégimeDeCirculation dataset.Codé[dataset.RégimeCirculation]
+	NombreDeVoies       dataset.Nombre
+	VoieSpéciale        dataset.Codé[dataset.VoieSpéciale]
+	Profil              dataset.Codé[dataset.Profil]
+	TracéEnPlan         dataset.Codé[dataset.TracéEnPlan]
+	ÉtatDeLaSurface     dataset.Codé[dataset.ÉtatSurface]
+	Aménagement         dataset.Codé[dataset.Aménagement]
+	Situation           dataset.Codé[dataset.Situation]
+	VitesseMaximale     dataset.Nombre
+}
+
+// A person's trip.
+type Trajet struct {
+	MotifDuTrajet       dataset.Codé[dataset.MotifTrajet]
+	PlaceDansLeVéhicule dataset.Nombre
+}
+
+type Personne struct {
+	IdAccident                   string
+	IdVéhicule                   string
+	NumVéhicule                  string
+	IdUsager                     string
+	Date                         dataset.Horodatage
+	Commune                      string
+	Adresse                      string
+	Latitude                     dataset.Coordonnée
+	Longitude                    dataset.Coordonnée
+	CatégorieDePersonne          CatégoriePersonne
+	Gravité                      dataset.Codé[dataset.Gravité]
+	AnnéeDeNaissance             int
+	Sexe                         dataset.Codé[dataset.Sexe]
+	VéhiculeQuiAHeurtéLePiéton   dataset.Codé[string]
+	LocalisationDuPiéton         dataset.Codé[string]
+	ActionDuPiéton               dataset.Codé[string]
+	PiétonSeulOuAccompagné       dataset.Codé[string]
+	ManœuvreDuVéhiculeQuiAHeurté dataset.Codé[string]
+	ConditionsAccident
+	Route
+	Trajet
+	dataset.Équipements
+}
+
+func (personne Personne) AsJson() (string, error) {
+	return dataset.ToJson(personne)
+}
+
+func (personne Personne) Position() (string, string) {
+	return string(personne.Latitude), string(personne.Longitude)
+}
+
+func (personne Personne) Titre() string {
+	return fmt.Sprintf("%v, %v (%v)", personne.CatégorieDePersonne, personne.Gravité, personne.Date[0:10])
+}
+
+func (personne Personne) NiveauDeGravité() dataset.Gravité {
+	return personne.Gravité.Valeur
+}
+
+func (personne Personne) Catégorie() string {
+	return personne.CatégorieDePersonne.String()
+}
+
+type ByDate []Personne
+
+func (slice ByDate) Len() int             { return len(slice) }
+func (slice ByDate) Swap(left, right int) { slice[left], slice[right] = slice[right], slice[left] }
+
+func (slice ByDate) Less(left, right int) bool {
+	if slice[left].Date != slice[right].Date {
+		return slice[left].Date < slice[right].Date
+	} else if slice[left].IdAccident != slice[right].IdAccident {
+		return slice[left].IdAccident < slice[right].IdAccident
+	} else {
+		return slice[left].IdUsager < slice[right].IdUsager
+	}
+}
+
+// Reads the accidents that match includeAccident, and writes a table of the people
+// involved in them who match personneOpts.
+func writePersonnes(personneOpts *PersonneOpts, includeAccident func(accident *dataset.Accident) bool) error {
+	var maybeOutputFile *string
+
+	if personneOpts.flags.Changed("out") {
+		maybeOutputFile = &personneOpts.outputFile
+	}
+
+	if err := checkOutputFormat(personneOpts.format); err != nil {
+		return err
+	}
+
+	outputOpts, err := personneOpts.outputOpts()
+
+	if err != nil {
+		return err
+	}
+
+	var personnes []Personne
+
+	err = forEachPersonne(personneOpts, includeAccident,
+		func(accident *dataset.Accident, véhicule *dataset.Véhicule, usager *dataset.Usager) {
+			personnes = append(personnes, makePersonne(accident, véhicule, usager))
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	sort.Sort(ByDate(personnes))
+	tableau := dataset.NewTableau(colonnesPersonne(personneOpts), personnes)
+	return writeRows(tableau, maybeOutputFile, personneOpts.format, outputOpts)
+}
+
+// Reads the accidents that match includeAccident and the filter options, and calls
+// visit for each person involved in them who matches personneOpts. If no department
+// was specified, accidents in all departments are included.
+func forEachPersonne(
+	personneOpts *PersonneOpts,
+	includeAccident func(accident *dataset.Accident) bool,
+	visit func(accident *dataset.Accident, véhicule *dataset.Véhicule, usager *dataset.Usager),
+) error {
+	if !(personneOpts.includePedestrians ||
+		personneOpts.includeCyclists ||
+		personneOpts.includeEDP ||
+		personneOpts.includeOthersInVehicles) {
+		return errors.New("no user categories selected")
+	}
+
+	matchesFilters, err := accidentFilter(personneOpts)
+
+	if err != nil {
+		return err
+	}
+
+	matchesUsagerFilters, err := usagerFilter(personneOpts)
+
+	if err != nil {
+		return err
+	}
+
+	accidents, err := readAccidents()
+
+	if err != nil {
+		return err
+	}
+
+	filteredAccidents := dataset.Filter(accidents, func(accident *dataset.Accident) bool {
+		return (personneOpts.département == "" || accident.Département == personneOpts.département) &&
+			includeAccident(accident) &&
+			matchesFilters(accident)
+	})
+
+	for _, accident := range filteredAccidents {
+		for _, véhicule := range accident.Véhicules {
+			usagers := dataset.Filter(véhicule.Usagers, includePerson(personneOpts, matchesUsagerFilters, accident, véhicule))
+
+			for _, usager := range usagers {
+				visit(accident, véhicule, usager)
+			}
+		}
+
+		autresUsagers := dataset.Filter(accident.AutresUsagers, includePerson(personneOpts, matchesUsagerFilters, accident, nil))
+
+		for _, usager := range autresUsagers {
+			visit(accident, nil, usager)
+		}
+	}
+
+	return nil
+}
+
+func makePersonne(
+	accident *dataset.Accident,
+	véhicule *dataset.Véhicule,
+	usager *dataset.Usager,
+) Personne {
+	var véhiculeQuiAHeurtéLePiéton dataset.Codé[string]
+
+	if usager.CatégorieUsager == dataset.Piéton {
+		if véhicule != nil {
+			véhiculeQuiAHeurtéLePiéton = dataset.NewCodé(véhicule.CatégorieVéhicule.String(), véhicule.CodesOfficiels, "catv")
+		} else {
+			véhiculeQuiAHeurtéLePiéton.Valeur = dataset.CatégorieVéhiculeIndéterminable.String()
+		}
+	}
+
+	lieu := getLieu(accident)
+
+	var localisationDuPiéton, actionDuPiéton, piétonSeulOuAccompagné dataset.Codé[string]
+
+	if usager.CatégorieUsager == dataset.Piéton {
+		localisationDuPiéton = dataset.NewCodé(usager.LocalisationPiéton.String(), usager.CodesOfficiels, "locp")
+		actionDuPiéton = dataset.NewCodé(usager.ActionPiéton.String(), usager.CodesOfficiels, "actp")
+		piétonSeulOuAccompagné = dataset.NewCodé(usager.ÉtatPiéton.String(), usager.CodesOfficiels, "etatp")
+	}
+
+	var manœuvreDuVéhiculeQuiAHeurté dataset.Codé[string]
+
+	if véhiculeQuiAHeurté := getVéhiculeQuiAHeurté(accident, véhicule, usager); véhiculeQuiAHeurté != nil {
+		manœuvreDuVéhiculeQuiAHeurté = dataset.NewCodé(
+			véhiculeQuiAHeurté.Manœuvre.String(),
+			véhiculeQuiAHeurté.CodesOfficiels,
+			"manv",
+		)
+	}
+
+	return Personne{
+		IdAccident:                   accident.IdAccident,
+		IdVéhicule:                   usager.IdVéhicule,
+		NumVéhicule:                  usager.NumVéhicule,
+		IdUsager:                     usager.IdUsager,
+		Date:                         dataset.Horodatage(accident.Date),
+		Commune:                      formatCommune(accident.Commune),
+		Adresse:                      accident.Adresse,
+		Latitude:                     dataset.Coordonnée(accident.Latitude),
+		Longitude:                    dataset.Coordonnée(accident.Longitude),
+		CatégorieDePersonne:          getCatégoriePersonne(usager, véhicule),
+		Gravité:                      dataset.NewCodé(usager.Gravité, usager.CodesOfficiels, "grav"),
+		AnnéeDeNaissance:             usager.AnnéeNaissance,
+		Sexe:                         dataset.NewCodé(usager.Sexe, usager.CodesOfficiels, "sexe"),
+		VéhiculeQuiAHeurtéLePiéton:   véhiculeQuiAHeurtéLePiéton,
+		LocalisationDuPiéton:         localisationDuPiéton,
+		ActionDuPiéton:               actionDuPiéton,
+		PiétonSeulOuAccompagné:       piétonSeulOuAccompagné,
+		ManœuvreDuVéhiculeQuiAHeurté: manœuvreDuVéhiculeQuiAHeurté,
+		ConditionsAccident: ConditionsAccident{
+			Luminosité:               dataset.NewCodé(accident.Luminosité, accident.CodesOfficiels, "lum"),
+			Agglomération:            dataset.NewCodé(accident.Agglomération, accident.CodesOfficiels, "agg"),
+			Intersection:             dataset.NewCodé(accident.Intersection, accident.CodesOfficiels, "int"),
+			ConditionsAtmosphériques: dataset.NewCodé(accident.ConditionsAtmosphériques, accident.CodesOfficiels, "atm"),
+			TypeDeCollision:          dataset.NewCodé(accident.TypeCollision, accident.CodesOfficiels, "col"),
+		},
+		Route: Route{
+			CatégorieDeRoute:    dataset.NewCodé(lieu.CatégorieRoute, lieu.CodesOfficiels, "catr"),
+			RégimeDeCirculation: dataset.NewCodé(lieu.RégimeCirculation, lieu.CodesOfficiels, "circ"),
+			NombreDeVoies:       lieu.NombreVoies,
+			VoieSpéciale:        dataset.NewCodé(lieu.VoieSpéciale, lieu.CodesOfficiels, "vosp"),
+			Profil:              dataset.NewCodé(lieu.Profil, lieu.CodesOfficiels, "prof"),
+			TracéEnPlan:         dataset.NewCodé(lieu.TracéEnPlan, lieu.CodesOfficiels, "plan"),
+			ÉtatDeLaSurface:     dataset.NewCodé(lieu.ÉtatSurface, lieu.CodesOfficiels, "surf"),
+			Aménagement:         dataset.NewCodé(lieu.Aménagement, lieu.CodesOfficiels, "infra"),
+			Situation:           dataset.NewCodé(lieu.Situation, lieu.CodesOfficiels, "situ"),
+			VitesseMaximale:     lieu.VitesseMaximale,
+		},
+		Trajet: Trajet{
+			MotifDuTrajet:       dataset.NewCodé(usager.MotifTrajet, usager.CodesOfficiels, "trajet"),
+			PlaceDansLeVéhicule: usager.Place,
+		},
+		Équipements: usager.Équipements,
+	}
+}
+
+// Returns the columns of a table of people. Pedestrians' columns are only included
+// if pedestrians are, and groups of optional columns only if they were requested.
+func colonnesPersonne(personneOpts *PersonneOpts) []dataset.Colonne {
+	colonnes := []dataset.Colonne{
+		dataset.ColonneTexte("Id accident", func(personne Personne) string { return personne.IdAccident }),
+		dataset.ColonneTexte("Id véhicule", func(personne Personne) string { return personne.IdVéhicule }),
+		dataset.ColonneTexte("Num véhicule", func(personne Personne) string { return personne.NumVéhicule }),
+		dataset.ColonneTexte("Id usager", func(personne Personne) string { return personne.IdUsager }),
+		dataset.ColonneHorodatage("Date", func(personne Personne) dataset.Horodatage { return personne.Date }),
+		dataset.ColonneTexte("Commune", func(personne Personne) string { return personne.Commune }),
+		dataset.ColonneTexte("Adresse", func(personne Personne) string { return personne.Adresse }),
+		dataset.ColonneCoordonnée("Latitude", func(personne Personne) dataset.Coordonnée { return personne.Latitude }),
+		dataset.ColonneCoordonnée("Longitude", func(personne Personne) dataset.Coordonnée { return personne.Longitude }),
+		dataset.ColonneCatégorie("Catégorie de personne", func(personne Personne) CatégoriePersonne {
+			return personne.CatégorieDePersonne
+		}),
+		dataset.ColonneCatégorie("Gravité", func(personne Personne) dataset.Codé[dataset.Gravité] { return personne.Gravité }),
+		dataset.ColonneEntier("Année de naissance", func(personne Personne) int { return personne.AnnéeDeNaissance }),
+		dataset.ColonneCatégorie("Sexe", func(personne Personne) dataset.Codé[dataset.Sexe] { return personne.Sexe }),
+	}
+
+	if personneOpts.includePedestrians {
+		colonnes = append(colonnes,
+			dataset.ColonneCatégorie("Véhicule qui a heurté le piéton", func(personne Personne) dataset.Codé[string] {
+				return personne.VéhiculeQuiAHeurtéLePiéton
+			}),
+			dataset.ColonneCatégorie("Localisation du piéton", func(personne Personne) dataset.Codé[string] {
+				return personne.LocalisationDuPiéton
+			}),
+			dataset.ColonneCatégorie("Action du piéton", func(personne Personne) dataset.Codé[string] {
+				return personne.ActionDuPiéton
+			}),
+			dataset.ColonneCatégorie("Piéton seul ou accompagné", func(personne Personne) dataset.Codé[string] {
+				return personne.PiétonSeulOuAccompagné
+			}),
+		)
+	}
+
+	colonnes = append(colonnes,
+		dataset.ColonneCatégorie("Manœuvre du véhicule qui a heurté", func(personne Personne) dataset.Codé[string] {
+			return personne.ManœuvreDuVéhiculeQuiAHeurté
+		}),
+	)
+
+	if personneOpts.includeConditions {
+		colonnes = append(colonnes,
+			dataset.ColonneCatégorie("Luminosité", func(personne Personne) dataset.Codé[dataset.Luminosité] {
+				return personne.Luminosité
+			}),
+			dataset.ColonneCatégorie("Agglomération", func(personne Personne) dataset.Codé[dataset.Agglomération] {
+				return personne.Agglomération
+			}),
+			dataset.ColonneCatégorie("Intersection", func(personne Personne) dataset.Codé[dataset.Intersection] {
+				return personne.Intersection
+			}),
+			dataset.ColonneCatégorie("Conditions atmosphériques", func(personne Personne) dataset.Codé[dataset.ConditionsAtmosphériques] {
+				return personne.ConditionsAtmosphériques
+			}),
+			dataset.ColonneCatégorie("Type de collision", func(personne Personne) dataset.Codé[dataset.TypeCollision] {
+				return personne.TypeDeCollision
+			}),
+		)
+	}
+
+	if personneOpts.includeRoad {
+		colonnes = append(colonnes,
+			dataset.ColonneCatégorie("Catégorie de route", func(personne Personne) dataset.Codé[dataset.CatégorieRoute] {
+				return personne.CatégorieDeRoute
+			}),
+			dataset.ColonneCatégorie("Régime de circulation", func(personne Personne) dataset.Codé[dataset.RégimeCirculation] {
+				return personne.RégimeDeCirculation
+			}),
+			dataset.ColonneNombre("Nombre de voies", func(personne Personne) dataset.Nombre { return personne.NombreDeVoies }),
+			dataset.ColonneCatégorie("Voie spéciale", func(personne Personne) dataset.Codé[dataset.VoieSpéciale] {
+				return personne.VoieSpéciale
+			}),
+			dataset.ColonneCatégorie("Profil", func(personne Personne) dataset.Codé[dataset.Profil] { return personne.Profil }),
+			dataset.ColonneCatégorie("Tracé en plan", func(personne Personne) dataset.Codé[dataset.TracéEnPlan] {
+				return personne.TracéEnPlan
+			}),
+			dataset.ColonneCatégorie("État de la surface", func(personne Personne) dataset.Codé[dataset.ÉtatSurface] {
+				return personne.ÉtatDeLaSurface
+			}),
+			dataset.ColonneCatégorie("Aménagement", func(personne Personne) dataset.Codé[dataset.Aménagement] {
+				return personne.Aménagement
+			}),
+			dataset.ColonneCatégorie("Situation", func(personne Personne) dataset.Codé[dataset.Situation] {
+				return personne.Situation
+			}),
+			dataset.ColonneNombre("Vitesse maximale", func(personne Personne) dataset.Nombre { return personne.VitesseMaximale }),
+		)
+	}
+
+	if personneOpts.includeTrip {
+		colonnes = append(colonnes,
+			dataset.ColonneCatégorie("Motif du trajet", func(personne Personne) dataset.Codé[dataset.MotifTrajet] {
+				return personne.MotifDuTrajet
+			}),
+			dataset.ColonneNombre("Place dans le véhicule", func(personne Personne) dataset.Nombre {
+				return personne.PlaceDansLeVéhicule
+			}),
+		)
+	}
+
+	if personneOpts.includeEquipment {
+		colonnes = append(colonnes,
+			dataset.ColonneCatégorie("Ceinture", func(personne Personne) dataset.UtilisationÉquipement { return personne.Ceinture }),
+			dataset.ColonneCatégorie("Casque", func(personne Personne) dataset.UtilisationÉquipement { return personne.Casque }),
+			dataset.ColonneCatégorie("Dispositif enfants", func(personne Personne) dataset.UtilisationÉquipement {
+				return personne.DispositifEnfants
+			}),
+			dataset.ColonneCatégorie("Équipement réfléchissant", func(personne Personne) dataset.UtilisationÉquipement {
+				return personne.ÉquipementRéfléchissant
+			}),
+			dataset.ColonneCatégorie("Airbag", func(personne Personne) dataset.UtilisationÉquipement { return personne.Airbag }),
+			dataset.ColonneCatégorie("Gants", func(personne Personne) dataset.UtilisationÉquipement { return personne.Gants }),
+			dataset.ColonneCatégorie("Autre équipement", func(personne Personne) dataset.UtilisationÉquipement {
+				return personne.AutreÉquipement
+			}),
+		)
+	}
+
+	return colonnes
+}
+
+// Returns the vehicle that hit a pedestrian, a cyclist or a user of a personal mobility
+// device, or nil if there isn't one or it can't be determined. A pedestrian is associated
+// with the vehicle that hit them. The vehicle that hit a cyclist is only known if there
+// was only one other vehicle in the accident.
+func getVéhiculeQuiAHeurté(accident *dataset.Accident, véhicule *dataset.Véhicule, usager *dataset.Usager) *dataset.Véhicule {
+	if usager.CatégorieUsager == dataset.Piéton || usager.CatégorieUsager == dataset.PiétonEnRollerOuTrottinette {
+		return véhicule
+	}
+
+	switch getCatégoriePersonne(usager, véhicule) {
+	case CatégoriePersonneCycliste, CatégoriePersonneEDP:
+		autresVéhicules := dataset.Filter(accident.Véhicules, func(autreVéhicule *dataset.Véhicule) bool {
+			return autreVéhicule != véhicule
+		})
+
+		if len(autresVéhicules) == 1 {
+			return autresVéhicules[0]
+		}
+	}
+
+	return nil
+}
+
+// Returns the place where an accident took place, or an empty place if it is not
+// in the data.
+func getLieu(accident *dataset.Accident) *dataset.Lieu {
+	if accident.Lieu == nil {
+		return &dataset.Lieu{IdAccident: accident.IdAccident}
+	} else {
+		return accident.Lieu
+	}
+}
+
+func formatCommune(commune *int) string {
+	if commune == nil {
+		return ""
+	} else {
+		return fmt.Sprint(*commune)
+	}
+}
+
+func includePerson(
+	personneOpts *PersonneOpts,
+	matchesUsagerFilters func(usager *dataset.Usager, accident *dataset.Accident) bool,
+	accident *dataset.Accident,
+	véhicule *dataset.Véhicule,
+) func(usager *dataset.Usager) bool {
+	return func(usager *dataset.Usager) bool {
+		catégoriePersonne := getCatégoriePersonne(usager, véhicule)
+
+		return ((personneOpts.includePedestrians && catégoriePersonne == CatégoriePersonnePiéton) ||
+			(personneOpts.includeCyclists && catégoriePersonne == CatégoriePersonneCycliste) ||
+			(personneOpts.includeEDP && catégoriePersonne == CatégoriePersonneEDP) ||
+			(personneOpts.includeOthersInVehicles && catégoriePersonne == CatégoriePersonneAutre)) &&
+			matchesUsagerFilters(usager, accident)
+	}
+}
+
+func wasMinor(usager *dataset.Usager, accident *dataset.Accident) bool {
+	accidentYear, _ := strconv.Atoi(accident.Date[0:4])
+	return accidentYear-usager.AnnéeNaissance < 18
+}
@@ -0,0 +1,587 @@
+package cmd
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benjamingeer/accicalc/internal/dataset"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd *cobra.Command = &cobra.Command{
+	Use:   "report",
+	Short: "Generate an HTML report on the victims of traffic accidents in a department or in particular communes.",
+	Long: `Generate an HTML report on the victims of traffic accidents in a department or in particular communes,
+with charts showing trends by year, a breakdown by age and sex, a plot of the positions of victims and a table of victims.
+The report is a single file that can be opened without a network connection.
+Example:
+
+accicalc report --department 94 --commune 33 --pedestrians --cyclists --startYear 2018 --out report.html
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		handleError(report)
+	},
+	Args: cobra.NoArgs,
+}
+
+type ReportOpts struct {
+	PersonneOpts
+	communes []uint
+}
+
+var reportOpts = ReportOpts{}
+
+//go:embed report.html
+var reportTemplateText string
+
+var reportTemplate = template.Must(template.New("report").Parse(reportTemplateText))
+
+func init() {
+	addPersonneFilterFlags(reportCmd.Flags(), &reportOpts.PersonneOpts)
+	_ = reportCmd.MarkFlagRequired("department")
+	reportCmd.Flags().UintSliceVarP(&reportOpts.communes, "commune", "c", nil, "commune number (can be repeated)")
+	rootCmd.AddCommand(reportCmd)
+}
+
+// The contents of a report.
+type Rapport struct {
+	Titre            string
+	Période          string
+	Date             string
+	NombreDeVictimes int
+	NombreDeTués     int
+	ParCatégorie     template.HTML
+	ParGravité       template.HTML
+	ParÂgeEtSexe     []LigneÂgeEtSexe
+	Carte            template.HTML
+	SansPosition     int
+	EnTêtes          []string
+	Victimes         [][]string
+}
+
+type LigneÂgeEtSexe struct {
+	Âge                              string
+	Hommes, Femmes, SexeNonRenseigné int
+	Total                            int
+	LargeurHommes, LargeurFemmes     float64
+}
+
+// A series of values in a chart.
+type série struct {
+	libellé string
+	couleur string
+}
+
+var sériesCatégories = []série{
+	{CatégoriePersonnePiéton.String(), "#1f77b4"},
+	{CatégoriePersonneCycliste.String(), "#2ca02c"},
+	{CatégoriePersonneEDP.String(), "#9467bd"},
+	{CatégoriePersonneAutre.String(), "#7f7f7f"},
+}
+
+var gravitésVictimes = []dataset.Gravité{dataset.Tué, dataset.BlesséHospitalisé, dataset.BlesséLéger}
+
+var couleursGravités = map[dataset.Gravité]string{
+	dataset.Tué:               "#b71c1c",
+	dataset.BlesséHospitalisé: "#ff9800",
+	dataset.BlesséLéger:       "#fbc02d",
+}
+
+func report() error {
+	var maybeOutputFile *string
+
+	if reportOpts.flags.Changed("out") {
+		maybeOutputFile = &reportOpts.outputFile
+	}
+
+	var victimes []Personne
+
+	err := forEachPersonne(&reportOpts.PersonneOpts,
+		func(accident *dataset.Accident) bool {
+			return len(reportOpts.communes) == 0 ||
+				(accident.Commune != nil && slices.Contains(reportOpts.communes, uint(*accident.Commune)))
+		},
+		func(accident *dataset.Accident, véhicule *dataset.Véhicule, usager *dataset.Usager) {
+			if isVictim(usager) {
+				victimes = append(victimes, makePersonne(accident, véhicule, usager))
+			}
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	sort.Sort(ByDate(victimes))
+
+	rapport, err := makeRapport(victimes)
+
+	if err != nil {
+		return err
+	}
+
+	var html bytes.Buffer
+
+	if err := reportTemplate.Execute(&html, rapport); err != nil {
+		return err
+	}
+
+	if maybeOutputFile == nil {
+		_, err = os.Stdout.Write(html.Bytes())
+		return err
+	}
+
+	return os.WriteFile(*maybeOutputFile, html.Bytes(), 0644)
+}
+
+func makeRapport(victimes []Personne) (Rapport, error) {
+	lieu := fmt.Sprintf("département %v", reportOpts.département)
+
+	if len(reportOpts.communes) > 0 {
+		var communes []string
+
+		for _, commune := range reportOpts.communes {
+			communes = append(communes, fmt.Sprint(commune))
+		}
+
+		lieu = fmt.Sprintf("%v, commune(s) %v", lieu, strings.Join(communes, ", "))
+	}
+
+	var années []int
+
+	for année := int(opts.startYear); année <= int(opts.endYear); année++ {
+		années = append(années, année)
+	}
+
+	parCatégorie := make(map[int][]int)
+	parGravité := make(map[int][]int)
+
+	for _, année := range années {
+		parCatégorie[année] = make([]int, len(sériesCatégories))
+		parGravité[année] = make([]int, len(gravitésVictimes))
+	}
+
+	nombreDeTués := 0
+
+	for _, victime := range victimes {
+		t, err := victime.Date.Time()
+
+		if err != nil {
+			return Rapport{}, fmt.Errorf("invalid date %v for accident %v", victime.Date, victime.IdAccident)
+		}
+
+		parCatégorie[t.Year()][victime.CatégorieDePersonne]++
+		parGravité[t.Year()][slices.Index(gravitésVictimes, victime.Gravité.Valeur)]++
+
+		if victime.Gravité.Valeur == dataset.Tué {
+			nombreDeTués++
+		}
+	}
+
+	var sériesGravités []série
+
+	for _, gravité := range gravitésVictimes {
+		sériesGravités = append(sériesGravités, série{gravité.String(), couleursGravités[gravité]})
+	}
+
+	carte, sansPosition := carteDesVictimes(victimes)
+	tableau := dataset.NewTableau(colonnesPersonne(&reportOpts.PersonneOpts), victimes)
+	colonnes, err := tableau.ColonnesChoisies(dataset.OutputOpts{})
+
+	if err != nil {
+		return Rapport{}, err
+	}
+
+	var enTêtes []string
+
+	for _, colonne := range colonnes {
+		enTêtes = append(enTêtes, colonne.Libellé)
+	}
+
+	var lignes [][]string
+
+	for _, ligne := range tableau.Lignes {
+		var cellules []string
+
+		for _, colonne := range colonnes {
+			cellules = append(cellules, colonne.Texte(ligne, dataset.OutputOpts{}))
+		}
+
+		lignes = append(lignes, cellules)
+	}
+
+	return Rapport{
+		Titre:            fmt.Sprintf("Victimes d'accidents de la circulation : %v", lieu),
+		Période:          fmt.Sprintf("%v–%v", opts.startYear, opts.endYear),
+		Date:             time.Now().Format("2006-01-02"),
+		NombreDeVictimes: len(victimes),
+		NombreDeTués:     nombreDeTués,
+		ParCatégorie:     graphiqueEmpilé(années, sériesCatégories, parCatégorie),
+		ParGravité:       graphiqueEmpilé(années, sériesGravités, parGravité),
+		ParÂgeEtSexe:     parÂgeEtSexe(victimes),
+		Carte:            carte,
+		SansPosition:     sansPosition,
+		EnTêtes:          enTêtes,
+		Victimes:         lignes,
+	}, nil
+}
+
+func parÂgeEtSexe(victimes []Personne) []LigneÂgeEtSexe {
+	lignes := make([]LigneÂgeEtSexe, TrancheÂge75EtPlus+1)
+
+	for trancheÂge := range lignes {
+		lignes[trancheÂge].Âge = TrancheÂge(trancheÂge).String()
+	}
+
+	for _, victime := range victimes {
+		ligne := &lignes[âgeDeVictime(victime)]
+
+		switch victime.Sexe.Valeur {
+		case dataset.Masculin:
+			ligne.Hommes++
+		case dataset.Féminin:
+			ligne.Femmes++
+		default:
+			ligne.SexeNonRenseigné++
+		}
+
+		ligne.Total++
+	}
+
+	maximum := 1
+
+	for _, ligne := range lignes {
+		maximum = max(maximum, ligne.Hommes, ligne.Femmes)
+	}
+
+	// The rows for known ages come first.
+	lignes = append(lignes[1:], lignes[0])
+
+	for index := range lignes {
+		lignes[index].LargeurHommes = 100 * float64(lignes[index].Hommes) / float64(maximum)
+		lignes[index].LargeurFemmes = 100 * float64(lignes[index].Femmes) / float64(maximum)
+	}
+
+	return lignes
+}
+
+func âgeDeVictime(victime Personne) TrancheÂge {
+	usager := dataset.Usager{AnnéeNaissance: victime.AnnéeDeNaissance}
+	accident := dataset.Accident{Date: string(victime.Date)}
+	return getTrancheÂge(&usager, &accident)
+}
+
+const (
+	largeurGraphique = 720
+	hauteurGraphique = 280
+	margeGauche      = 40
+	margeHaut        = 8
+	margeBas         = 24
+	hauteurLégende   = 28
+)
+
+// Returns an SVG bar chart with a bar for each year, divided into series.
+func graphiqueEmpilé(années []int, séries []série, valeurs map[int][]int) template.HTML {
+	maximum := 1
+
+	for _, année := range années {
+		total := 0
+
+		for _, valeur := range valeurs[année] {
+			total += valeur
+		}
+
+		maximum = max(maximum, total)
+	}
+
+	pas := pasDeGraduation(maximum)
+	maximum = int(math.Ceil(float64(maximum)/float64(pas))) * pas
+	hauteurZone := float64(hauteurGraphique - margeHaut - margeBas)
+	largeurBarre := float64(largeurGraphique-margeGauche) / float64(len(années))
+	var svg strings.Builder
+
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" class="graphique">`,
+		largeurGraphique, hauteurGraphique+hauteurLégende)
+
+	for graduation := 0; graduation <= maximum; graduation += pas {
+		y := margeHaut + hauteurZone - hauteurZone*float64(graduation)/float64(maximum)
+		fmt.Fprintf(&svg, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" class="grille"/>`, margeGauche, y, largeurGraphique, y)
+		fmt.Fprintf(&svg, `<text x="%d" y="%.1f" class="graduation">%d</text>`, margeGauche-6, y+4, graduation)
+	}
+
+	for index, année := range années {
+		x := float64(margeGauche) + largeurBarre*float64(index)
+		y := margeHaut + hauteurZone
+
+		for indexSérie, valeur := range valeurs[année] {
+			if valeur == 0 {
+				continue
+			}
+
+			hauteur := hauteurZone * float64(valeur) / float64(maximum)
+			y -= hauteur
+
+			fmt.Fprintf(&svg, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%v"><title>%d, %v : %d</title></rect>`,
+				x+largeurBarre*0.15, y, largeurBarre*0.7, hauteur, séries[indexSérie].couleur,
+				année, template.HTMLEscapeString(séries[indexSérie].libellé), valeur)
+		}
+
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%d" class="année">%d</text>`,
+			x+largeurBarre/2, hauteurGraphique-6, année)
+	}
+
+	x := margeGauche
+
+	for _, série := range séries {
+		fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="12" height="12" fill="%v"/>`, x, hauteurGraphique+8, série.couleur)
+		fmt.Fprintf(&svg, `<text x="%d" y="%d" class="légende">%v</text>`,
+			x+16, hauteurGraphique+18, template.HTMLEscapeString(série.libellé))
+		x += 24 + 8*len([]rune(série.libellé))
+	}
+
+	svg.WriteString(`</svg>`)
+	return template.HTML(svg.String())
+}
+
+// Returns a step between graduations that gives at most about 8 graduations.
+func pasDeGraduation(maximum int) int {
+	for _, pas := range []int{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000} {
+		if maximum/pas <= 8 {
+			return pas
+		}
+	}
+
+	return 10000
+}
+
+const (
+	largeurCarte  = 720
+	hauteurCarte  = 480
+	margeCarte    = 40
+	kmParDegré    = 111.32
+	maxÉtiquettes = 40
+)
+
+// Returns an SVG plot of the position of each victim, coloured according to the
+// severity of their injuries, and the number of victims whose position is unknown.
+// No street map can be embedded without network access, so the plot gives its
+// bearings with a grid of coordinates, a scale bar and the addresses of the
+// accidents.
+func carteDesVictimes(victimes []Personne) (template.HTML, int) {
+	type point struct {
+		latitude, longitude float64
+		victime             Personne
+	}
+
+	var points []point
+	sansPosition := 0
+
+	for _, victime := range victimes {
+		latitude, longitude, ok := dataset.ParsePosition(victime.Position())
+
+		if ok {
+			points = append(points, point{latitude, longitude, victime})
+		} else {
+			sansPosition++
+		}
+	}
+
+	if len(points) == 0 {
+		return "", sansPosition
+	}
+
+	minLatitude, maxLatitude := points[0].latitude, points[0].latitude
+	minLongitude, maxLongitude := points[0].longitude, points[0].longitude
+
+	for _, point := range points {
+		minLatitude, maxLatitude = min(minLatitude, point.latitude), max(maxLatitude, point.latitude)
+		minLongitude, maxLongitude = min(minLongitude, point.longitude), max(maxLongitude, point.longitude)
+	}
+
+	// An equirectangular projection is accurate enough at the scale of a department.
+	facteurLongitude := math.Cos((minLatitude + maxLatitude) / 2 * math.Pi / 180)
+	largeur := max((maxLongitude-minLongitude)*facteurLongitude, 0.002)
+	hauteur := max(maxLatitude-minLatitude, 0.002)
+	échelle := min(float64(largeurCarte-2*margeCarte)/largeur, float64(hauteurCarte-2*margeCarte)/hauteur)
+	centreLongitude := (minLongitude + maxLongitude) / 2
+	centreLatitude := (minLatitude + maxLatitude) / 2
+
+	versX := func(longitude float64) float64 {
+		return largeurCarte/2 + (longitude-centreLongitude)*facteurLongitude*échelle
+	}
+
+	versY := func(latitude float64) float64 {
+		return hauteurCarte/2 - (latitude-centreLatitude)*échelle
+	}
+
+	var svg strings.Builder
+
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" class="carte">`, largeurCarte, hauteurCarte)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" class="fond"/>`, largeurCarte, hauteurCarte)
+	écrireGrille(&svg, versX, versY, facteurLongitude, échelle, centreLongitude, centreLatitude)
+	écrireÉchelle(&svg, échelle)
+
+	// Points for the most serious injuries are drawn last, so they are visible.
+	slices.SortStableFunc(points, func(left, right point) int {
+		return slices.Index(gravitésVictimes, right.victime.Gravité.Valeur) -
+			slices.Index(gravitésVictimes, left.victime.Gravité.Valeur)
+	})
+
+	for _, point := range points {
+		fmt.Fprintf(&svg, `<circle cx="%.1f" cy="%.1f" r="5" fill="%v"><title>%v</title></circle>`,
+			versX(point.longitude), versY(point.latitude), couleursGravités[point.victime.Gravité.Valeur],
+			template.HTMLEscapeString(fmt.Sprintf("%v, %v", point.victime.Titre(), point.victime.Adresse)),
+		)
+	}
+
+	// Label each position with its address, starting with the positions with the
+	// most victims, and leaving out labels that would overlap others.
+	type étiquette struct {
+		x, y    float64
+		adresse string
+		nombre  int
+	}
+
+	étiquettesParPosition := make(map[[2]int]*étiquette)
+	var étiquettes []*étiquette
+
+	for _, point := range points {
+		if point.victime.Adresse == "" {
+			continue
+		}
+
+		x, y := versX(point.longitude), versY(point.latitude)
+		clé := [2]int{int(x), int(y)}
+
+		if existing, ok := étiquettesParPosition[clé]; ok {
+			existing.nombre++
+		} else {
+			étiquettesParPosition[clé] = &étiquette{x, y, point.victime.Adresse, 1}
+			étiquettes = append(étiquettes, étiquettesParPosition[clé])
+		}
+	}
+
+	slices.SortStableFunc(étiquettes, func(left, right *étiquette) int {
+		return right.nombre - left.nombre
+	})
+
+	var placées [][4]float64
+
+	for _, étiquette := range étiquettes {
+		if len(placées) == maxÉtiquettes {
+			break
+		}
+
+		texte := []rune(étiquette.adresse)
+
+		if len(texte) > 40 {
+			texte = append(texte[:39], '…')
+		}
+
+		// Labels go to the right of the point, or to the left near the right edge.
+		largeurTexte := 6 * float64(len(texte))
+		x, ancre := étiquette.x+8, "start"
+		rectangle := [4]float64{x, étiquette.y - 10, x + largeurTexte, étiquette.y + 3}
+
+		if rectangle[2] > largeurCarte {
+			x, ancre = étiquette.x-8, "end"
+			rectangle = [4]float64{x - largeurTexte, étiquette.y - 10, x, étiquette.y + 3}
+		}
+
+		chevauche := slices.ContainsFunc(placées, func(autre [4]float64) bool {
+			return rectangle[0] < autre[2] && autre[0] < rectangle[2] && rectangle[1] < autre[3] && autre[1] < rectangle[3]
+		})
+
+		if chevauche {
+			continue
+		}
+
+		placées = append(placées, rectangle)
+		fmt.Fprintf(&svg, `<text x="%.1f" y="%.1f" text-anchor="%v" class="adresse">%v</text>`,
+			x, étiquette.y+1, ancre, template.HTMLEscapeString(string(texte)))
+	}
+
+	svg.WriteString(`</svg>`)
+	return template.HTML(svg.String()), sansPosition
+}
+
+// Draws lines of latitude and longitude, with their values in degrees, at a step
+// that gives a few lines across the plot.
+func écrireGrille(svg *strings.Builder, versX, versY func(float64) float64, facteurLongitude, échelle, centreLongitude, centreLatitude float64) {
+	demiLargeur := largeurCarte / 2 / (facteurLongitude * échelle)
+	demiHauteur := hauteurCarte / 2 / échelle
+	pas := pasDeGrille(2 * max(demiLargeur, demiHauteur))
+	décimales := max(0, int(math.Ceil(-math.Log10(pas))))
+
+	for longitude := math.Ceil((centreLongitude-demiLargeur)/pas) * pas; longitude <= centreLongitude+demiLargeur; longitude += pas {
+		x := versX(longitude)
+		fmt.Fprintf(svg, `<line x1="%.1f" y1="0" x2="%.1f" y2="%d" class="grille"/>`, x, x, hauteurCarte)
+		fmt.Fprintf(svg, `<text x="%.1f" y="%d" class="coordonnée">%v</text>`, x+2, hauteurCarte-4, formatDegrés(longitude, décimales, "E", "W"))
+	}
+
+	for latitude := math.Ceil((centreLatitude-demiHauteur)/pas) * pas; latitude <= centreLatitude+demiHauteur; latitude += pas {
+		y := versY(latitude)
+		fmt.Fprintf(svg, `<line x1="0" y1="%.1f" x2="%d" y2="%.1f" class="grille"/>`, y, largeurCarte, y)
+		fmt.Fprintf(svg, `<text x="2" y="%.1f" class="coordonnée">%v</text>`, y-2, formatDegrés(latitude, décimales, "N", "S"))
+	}
+}
+
+// Formats a latitude or longitude as a positive number of degrees followed by the
+// hemisphere, e.g. "61.5° W" for -61.5 with the letters E and W.
+func formatDegrés(valeur float64, décimales int, positif string, négatif string) string {
+	texte := strconv.FormatFloat(math.Abs(valeur), 'f', décimales, 64)
+
+	if strings.Trim(texte, "0.") == "" {
+		return texte + "°"
+	} else if valeur < 0 {
+		return texte + "° " + négatif
+	}
+
+	return texte + "° " + positif
+}
+
+// Returns a step in degrees that gives at most about 6 lines across a span.
+func pasDeGrille(étendue float64) float64 {
+	for _, pas := range []float64{0.001, 0.002, 0.005, 0.01, 0.02, 0.05, 0.1, 0.2, 0.5, 1} {
+		if étendue/pas <= 6 {
+			return pas
+		}
+	}
+
+	return 2
+}
+
+// Draws a scale bar in the top right corner, with a length in metres or
+// kilometres that takes up at most 150 pixels.
+func écrireÉchelle(svg *strings.Builder, échelle float64) {
+	pixelsParKm := échelle / kmParDegré
+	longueurKm := 0.05
+
+	for _, longueur := range []float64{0.1, 0.2, 0.5, 1, 2, 5, 10, 20, 50, 100} {
+		if longueur*pixelsParKm > 150 {
+			break
+		}
+
+		longueurKm = longueur
+	}
+
+	libellé := fmt.Sprintf("%v km", longueurKm)
+
+	if longueurKm < 1 {
+		libellé = fmt.Sprintf("%v m", longueurKm*1000)
+	}
+
+	x2 := float64(largeurCarte - 12)
+	x1 := x2 - longueurKm*pixelsParKm
+	fmt.Fprintf(svg, `<path d="M %.1f 14 V 20 H %.1f V 14" class="échelle"/>`, x1, x2)
+	fmt.Fprintf(svg, `<text x="%.1f" y="32" class="légende" text-anchor="middle">%v</text>`, (x1+x2)/2, libellé)
+}
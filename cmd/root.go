@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/benjamingeer/accicalc/internal/dataset"
 	"github.com/spf13/cobra"
@@ -15,9 +18,11 @@ var rootCmd *cobra.Command = &cobra.Command{
 }
 
 type Opts struct {
-	dataPath  string
-	startYear uint
-	endYear   uint
+	dataPath        string
+	startYear       uint
+	endYear         uint
+	inputDelimiters []string
+	inputEncodings  []string
 }
 
 var (
@@ -32,6 +37,10 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&opts.dataPath, "dataPath", "d", "./data", "path to directory of accident data")
 	rootCmd.PersistentFlags().UintVarP(&opts.startYear, "startYear", "s", dataset.FirstYear, "first year to process")
 	rootCmd.PersistentFlags().UintVarP(&opts.endYear, "endYear", "e", dataset.LastYear, "last year to process")
+	rootCmd.PersistentFlags().StringArrayVar(&opts.inputDelimiters, "input-delimiter", nil,
+		"delimiter of the data files for a year, if it isn't detected correctly, e.g. 2009=tab (can be repeated)")
+	rootCmd.PersistentFlags().StringArrayVar(&opts.inputEncodings, "input-encoding", nil,
+		"character encoding of the data files for a year, if it isn't detected correctly, e.g. 2010=windows-1252 (can be repeated)")
 }
 
 func Execute() {
@@ -48,17 +57,98 @@ func handleError(operation func() error) {
 	}
 }
 
-func readAccidents() (accidents []*dataset.Accident, err error) {
+func checkYears() error {
 	if opts.startYear < dataset.FirstYear || opts.startYear > dataset.LastYear {
-		return nil, fmt.Errorf("invalid start year %v", opts.startYear)
+		return fmt.Errorf("invalid start year %v", opts.startYear)
 	}
 
 	if opts.endYear < dataset.FirstYear || opts.endYear > dataset.LastYear {
-		return nil, fmt.Errorf("invalid end year %v", opts.endYear)
+		return fmt.Errorf("invalid end year %v", opts.endYear)
 	}
 
 	if opts.startYear > opts.endYear {
-		return nil, fmt.Errorf("start year cannot be later than end year")
+		return fmt.Errorf("start year cannot be later than end year")
+	}
+
+	return nil
+}
+
+// Parses a list of values of the form YEAR=VALUE.
+func parseYearValues(flag string, values []string, parseValue func(year uint, value string) error) error {
+	for _, yearValue := range values {
+		yearStr, value, ok := strings.Cut(yearValue, "=")
+
+		if !ok {
+			return fmt.Errorf("invalid value '%v' for --%v (expected YEAR=VALUE)", yearValue, flag)
+		}
+
+		year, err := strconv.ParseUint(yearStr, 10, 0)
+
+		if err != nil {
+			return fmt.Errorf("invalid year '%v' for --%v", yearStr, flag)
+		}
+
+		if err := parseValue(uint(year), value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sets the formats of the data files that were given with --input-delimiter and
+// --input-encoding.
+func setInputFormats() error {
+	err := parseYearValues("input-delimiter", opts.inputDelimiters, func(year uint, value string) error {
+		delimiter, err := parseDelimiter(value)
+
+		if err != nil {
+			return err
+		}
+
+		format := dataset.FormatsDEntrée[year]
+		format.Délimiteur = delimiter
+		dataset.FormatsDEntrée[year] = format
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return parseYearValues("input-encoding", opts.inputEncodings, func(year uint, value string) error {
+		format := dataset.FormatsDEntrée[year]
+
+		switch strings.ToLower(value) {
+		case "utf-8", "utf8":
+			format.Encodage = dataset.EncodageUtf8
+		case "windows-1252", "cp1252", "latin-1", "latin1", "iso-8859-1":
+			format.Encodage = dataset.EncodageWindows1252
+		default:
+			return fmt.Errorf("invalid encoding '%v' (expected utf-8 or windows-1252)", value)
+		}
+
+		dataset.FormatsDEntrée[year] = format
+		return nil
+	})
+}
+
+func readAccidents() (accidents []*dataset.Accident, err error) {
+	if err := checkYears(); err != nil {
+		return nil, err
+	}
+
+	if err := setInputFormats(); err != nil {
+		return nil, err
+	}
+
+	// If there is a manifest, warn about files that don't match it.
+	manifeste, err := dataset.ReadManifest(opts.dataPath)
+
+	if errors.Is(err, os.ErrNotExist) {
+		manifeste = nil
+	} else if err != nil {
+		return nil, err
 	}
 
 	var allAccidents []*dataset.Accident
@@ -66,26 +156,49 @@ func readAccidents() (accidents []*dataset.Accident, err error) {
 	for year := opts.startYear; year <= opts.endYear; year++ {
 		if yearDatasetReader, ok := dataset.YearDatasetReaders[year]; ok {
 			fmt.Fprintf(os.Stderr, "Reading data for %v...\n", year)
+			fichiers, err := dataset.LocateFiles(opts.dataPath, year)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, ambiguïté := range fichiers.Ambiguïtés {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", ambiguïté)
+			}
+
+			if manifeste != nil {
+				if err := warnIfDataFilesChanged(manifeste, fichiers); err != nil {
+					return nil, err
+				}
+			}
+
+			emplacements := make(map[dataset.Fichier]dataset.Emplacement)
+
+			for _, fichier := range dataset.Fichiers {
+				if emplacements[fichier], err = fichiers.Location(fichier); err != nil {
+					return nil, err
+				}
+			}
 
-			accidents, err := yearDatasetReader.ReadCharacteristics(year, opts.dataPath)
+			accidents, err := yearDatasetReader.ReadCharacteristics(year, emplacements[dataset.FichierCaractéristiques])
 
 			if err != nil {
 				return nil, err
 			}
 
-			places, err := yearDatasetReader.ReadPlaces(year, opts.dataPath)
+			places, err := yearDatasetReader.ReadPlaces(year, emplacements[dataset.FichierLieux])
 
 			if err != nil {
 				return nil, err
 			}
 
-			vehicles, err := yearDatasetReader.ReadVehicles(year, opts.dataPath)
+			vehicles, err := yearDatasetReader.ReadVehicles(year, emplacements[dataset.FichierVéhicules])
 
 			if err != nil {
 				return nil, err
 			}
 
-			users, err := yearDatasetReader.ReadUsers(year, opts.dataPath)
+			users, err := yearDatasetReader.ReadUsers(year, emplacements[dataset.FichierUsagers])
 
 			if err != nil {
 				return nil, err
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/benjamingeer/accicalc/internal/dataset"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd *cobra.Command = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the data files against the manifest in the data directory.",
+	Long: `Check the size and SHA-256 checksum of each data file against the manifest in the data
+directory, which is written by the fetch command. To create a manifest from a directory
+whose files are known to be good, use --generate. This replaces the manifest's entries for
+the chosen years with the files that are found for them now.
+Example:
+
+accicalc verify --startYear 2019
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		handleError(verify)
+	},
+	Args: cobra.NoArgs,
+}
+
+type VerifyOpts struct {
+	generate bool
+}
+
+var verifyOpts = VerifyOpts{}
+
+func init() {
+	verifyCmd.Flags().BoolVar(&verifyOpts.generate, "generate", false, "write a manifest describing the files in the data directory")
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func verify() error {
+	if err := checkYears(); err != nil {
+		return err
+	}
+
+	if verifyOpts.generate {
+		return generateManifest()
+	}
+
+	manifeste, err := dataset.ReadManifest(opts.dataPath)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no manifest in %v (use fetch, or verify --generate, to create one)", opts.dataPath)
+	} else if err != nil {
+		return err
+	}
+
+	checked := 0
+	mismatched := 0
+	found := make(map[string]bool)
+
+	for year := opts.startYear; year <= opts.endYear; year++ {
+		fichiers, err := dataset.LocateFiles(opts.dataPath, year)
+
+		if err != nil {
+			return err
+		}
+
+		for _, fichier := range dataset.Fichiers {
+			emplacement, ok := fichiers.Emplacements[fichier]
+
+			if !ok {
+				continue
+			}
+
+			found[emplacement.ManifestKey(opts.dataPath)] = true
+			problem, err := manifeste.Verify(opts.dataPath, emplacement, true)
+
+			if err != nil {
+				return err
+			}
+
+			checked++
+
+			if problem != "" {
+				fmt.Println(problem)
+				mismatched++
+			}
+		}
+	}
+
+	// Files in the manifest that weren't found are missing.
+	for _, clé := range manifeste.Keys() {
+		if year, ok := dataset.KeyYear(clé); ok && year >= opts.startYear && year <= opts.endYear && !found[clé] {
+			fmt.Printf("%v is missing\n", clé)
+			checked++
+			mismatched++
+		}
+	}
+
+	if mismatched > 0 {
+		return fmt.Errorf("%v of %v files don't match the manifest", mismatched, checked)
+	}
+
+	fmt.Printf("%v files match the manifest\n", checked)
+	return nil
+}
+
+// Writes a manifest describing the files for the chosen years, wherever they are
+// found, keeping the source and download date of files that haven't changed. The
+// entries for the chosen years are replaced, so that files that have been moved,
+// renamed or deleted are no longer listed, and entries for other years are kept.
+func generateManifest() error {
+	previous, err := dataset.ReadOrCreateManifest(opts.dataPath)
+
+	if err != nil {
+		return err
+	}
+
+	manifeste := dataset.NewManifeste()
+
+	for clé, entrée := range previous.Fichiers {
+		if year, ok := dataset.KeyYear(clé); ok && (year < opts.startYear || year > opts.endYear) {
+			manifeste.Fichiers[clé] = entrée
+		}
+	}
+
+	for year := opts.startYear; year <= opts.endYear; year++ {
+		fichiers, err := dataset.LocateFiles(opts.dataPath, year)
+
+		if err != nil {
+			return err
+		}
+
+		for _, fichier := range dataset.Fichiers {
+			emplacement, ok := fichiers.Emplacements[fichier]
+
+			if !ok {
+				continue
+			}
+
+			clé := emplacement.ManifestKey(opts.dataPath)
+			entrée, err := emplacement.Describe()
+
+			if err != nil {
+				return err
+			}
+
+			if previous, ok := previous.Fichiers[clé]; ok && previous.Sha256 == entrée.Sha256 {
+				entrée.Source = previous.Source
+				entrée.DateDeTéléchargement = previous.DateDeTéléchargement
+			}
+
+			manifeste.Fichiers[clé] = entrée
+		}
+	}
+
+	if err := manifeste.Write(opts.dataPath); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote manifest with %v files\n", len(manifeste.Fichiers))
+	return nil
+}
+
+// Adds a file that has just been downloaded to the manifest.
+func addToManifest(manifeste *dataset.Manifeste, fichier dataset.Fichier, year uint, source string) error {
+	emplacement := dataset.Emplacement{Chemin: dataset.FilePath(opts.dataPath, fichier, year)}
+	entrée, err := emplacement.Describe()
+
+	if err != nil {
+		return err
+	}
+
+	entrée.Source = source
+	entrée.DateDeTéléchargement = time.Now().UTC().Format(time.RFC3339)
+	manifeste.Fichiers[emplacement.ManifestKey(opts.dataPath)] = entrée
+	return manifeste.Write(opts.dataPath)
+}
+
+// Prints a warning for each of the files found for a year that doesn't match the
+// manifest.
+func warnIfDataFilesChanged(manifeste *dataset.Manifeste, fichiers dataset.FichiersAnnuels) error {
+	for _, fichier := range dataset.Fichiers {
+		emplacement, ok := fichiers.Emplacements[fichier]
+
+		if !ok {
+			continue
+		}
+
+		problem, err := manifeste.Verify(opts.dataPath, emplacement, false)
+
+		if err != nil {
+			return err
+		}
+
+		if problem != "" {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", problem)
+		}
+	}
+
+	return nil
+}
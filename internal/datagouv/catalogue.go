@@ -0,0 +1,190 @@
+// Package datagouv reads the dataset catalogue of data.gouv.fr and downloads
+// the resources that it lists.
+package datagouv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/benjamingeer/accicalc/internal/dataset"
+)
+
+// The base URL of the catalogue API of data.gouv.fr.
+const DefaultBaseUrl = "https://www.data.gouv.fr/api/1"
+
+// The identifier of the dataset containing the annual accident files. Unlike the
+// slug in its URL, which names the range of years, it doesn't change when a year
+// is added.
+const DefaultDatasetId = "53698f4ca3a729239d2036df"
+
+// A dataset as described by the catalogue API.
+type JeuDeDonnées struct {
+	Id         string      `json:"id"`
+	Titre      string      `json:"title"`
+	Ressources []Ressource `json:"resources"`
+}
+
+// A file belonging to a dataset.
+type Ressource struct {
+	Id                   string `json:"id"`
+	Titre                string `json:"title"`
+	Url                  string `json:"url"`
+	Format               string `json:"format"`
+	Taille               int64  `json:"filesize"`
+	DernièreModification string `json:"last_modified"`
+}
+
+// Returns the name of the file that the resource contains, taken from its URL,
+// or its title if the URL has no file name.
+func (ressource Ressource) FileName() string {
+	if parsedUrl, err := url.Parse(ressource.Url); err == nil {
+		if name := path.Base(parsedUrl.Path); strings.Contains(name, ".") {
+			return name
+		}
+	}
+
+	return ressource.Titre
+}
+
+// Identifies one of the files for a year.
+type CléFichier struct {
+	Year    uint
+	Fichier dataset.Fichier
+}
+
+// Returns the resources that are official data files, identified by their names.
+// If a file has been published more than once, the latest version is used, and the
+// duplicates are described.
+func (jeuDeDonnées *JeuDeDonnées) ResourcesByFile() (ressources map[CléFichier]Ressource, doublons []string) {
+	ressources = make(map[CléFichier]Ressource)
+
+	for _, ressource := range jeuDeDonnées.Ressources {
+		fichier, year, ok := dataset.IdentifyFile(ressource.FileName())
+
+		if !ok {
+			continue
+		}
+
+		clé := CléFichier{year, fichier}
+
+		if existing, exists := ressources[clé]; exists {
+			doublons = append(doublons, fmt.Sprintf("Found both %v and %v for %v %v",
+				existing.Url, ressource.Url, fichier, year))
+
+			if existing.DernièreModification >= ressource.DernièreModification {
+				continue
+			}
+		}
+
+		ressources[clé] = ressource
+	}
+
+	return ressources, doublons
+}
+
+// A client for the catalogue API. BaseUrl can be changed to use another server,
+// e.g. a local stand-in.
+type Client struct {
+	BaseUrl string
+	Http    *http.Client
+}
+
+func NewClient(baseUrl string) *Client {
+	return &Client{
+		BaseUrl: strings.TrimSuffix(baseUrl, "/"),
+		Http:    http.DefaultClient,
+	}
+}
+
+// Gets the description of a dataset, including its resources.
+func (client *Client) GetJeuDeDonnées(id string) (*JeuDeDonnées, error) {
+	datasetUrl := fmt.Sprintf("%v/datasets/%v/", client.BaseUrl, url.PathEscape(id))
+	response, err := client.get(datasetUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	var jeuDeDonnées JeuDeDonnées
+
+	if err := json.NewDecoder(response.Body).Decode(&jeuDeDonnées); err != nil {
+		return nil, fmt.Errorf("can't parse dataset description from %v: %w", datasetUrl, err)
+	}
+
+	return &jeuDeDonnées, nil
+}
+
+// Downloads a resource to a file. The file is written under a temporary name and
+// renamed when the download is complete, so an interrupted download never
+// leaves a partial file at destPath.
+func (client *Client) Download(ressource Ressource, destPath string) (written int64, err error) {
+	response, err := client.get(ressource.Url)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer response.Body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return 0, err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(destPath), "."+filepath.Base(destPath)+".*")
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			tempFile.Close()
+			os.Remove(tempFile.Name())
+		}
+	}()
+
+	written, err = io.Copy(tempFile, response.Body)
+
+	if err != nil {
+		return 0, fmt.Errorf("can't download %v: %w", ressource.Url, err)
+	}
+
+	if ressource.Taille > 0 && written != ressource.Taille {
+		err = fmt.Errorf("downloaded %v bytes from %v, expected %v", written, ressource.Url, ressource.Taille)
+		return 0, err
+	}
+
+	if err = tempFile.Close(); err != nil {
+		return 0, err
+	}
+
+	if err = os.Rename(tempFile.Name(), destPath); err != nil {
+		return 0, err
+	}
+
+	return written, nil
+}
+
+func (client *Client) get(url string) (*http.Response, error) {
+	response, err := client.Http.Get(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("can't get %v: %v", url, response.Status)
+	}
+
+	return response, nil
+}
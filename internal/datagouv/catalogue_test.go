@@ -0,0 +1,220 @@
+package datagouv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benjamingeer/accicalc/internal/dataset"
+)
+
+// A stand-in for data.gouv.fr, serving a dataset description and its files.
+type standIn struct {
+	server   *httptest.Server
+	fichiers map[string]string
+	tailles  map[string]int64
+	dates    map[string]string
+}
+
+func newStandIn(t *testing.T, fichiers map[string]string) *standIn {
+	standIn := &standIn{
+		fichiers: fichiers,
+		tailles:  make(map[string]int64),
+		dates:    make(map[string]string),
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/1/datasets/accidents/", func(writer http.ResponseWriter, request *http.Request) {
+		jeuDeDonnées := JeuDeDonnées{Id: "accidents", Titre: "Accidents"}
+
+		for nom, contenu := range standIn.fichiers {
+			taille := int64(len(contenu))
+
+			if tailleAnnoncée, ok := standIn.tailles[nom]; ok {
+				taille = tailleAnnoncée
+			}
+
+			date := "2024-01-01T00:00:00"
+
+			if dateAnnoncée, ok := standIn.dates[nom]; ok {
+				date = dateAnnoncée
+			}
+
+			// Resources with the same file name are distinguished by a directory, as on
+			// static.data.gouv.fr.
+			jeuDeDonnées.Ressources = append(jeuDeDonnées.Ressources, Ressource{
+				Id:                   nom,
+				Titre:                filepath.Base(nom),
+				Url:                  standIn.server.URL + "/files/" + nom,
+				Format:               "csv",
+				Taille:               taille,
+				DernièreModification: date,
+			})
+		}
+
+		_ = json.NewEncoder(writer).Encode(jeuDeDonnées)
+	})
+
+	mux.HandleFunc("/files/", func(writer http.ResponseWriter, request *http.Request) {
+		nom := strings.TrimPrefix(request.URL.Path, "/files/")
+
+		if contenu, ok := standIn.fichiers[nom]; ok {
+			_, _ = writer.Write([]byte(contenu))
+		} else {
+			http.Error(writer, "not found", http.StatusInternalServerError)
+		}
+	})
+
+	standIn.server = httptest.NewServer(mux)
+	t.Cleanup(standIn.server.Close)
+	return standIn
+}
+
+func (standIn *standIn) client() *Client {
+	return NewClient(standIn.server.URL + "/api/1/")
+}
+
+func TestNamingQuirksResolveToReaderPaths(t *testing.T) {
+	standIn := newStandIn(t, map[string]string{
+		"carcteristiques-2021.csv": "2021",
+		"caract-2023.csv":          "2023",
+		"lieux_2016.csv":           "2016",
+		"lieux-2017.csv":           "2017",
+		"description.pdf":          "pdf",
+	})
+
+	client := standIn.client()
+	jeuDeDonnées, err := client.GetJeuDeDonnées("accidents")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ressources, doublons := jeuDeDonnées.ResourcesByFile()
+
+	if len(ressources) != 4 || len(doublons) != 0 {
+		t.Fatalf("expected 4 resources and no duplicates, got %v and %v", ressources, doublons)
+	}
+
+	dataPath := t.TempDir()
+
+	for _, expected := range []struct {
+		clé     CléFichier
+		chemin  string
+		contenu string
+	}{
+		{CléFichier{2021, dataset.FichierCaractéristiques}, "2021/carcteristiques-2021.csv", "2021"},
+		{CléFichier{2023, dataset.FichierCaractéristiques}, "2023/caract-2023.csv", "2023"},
+		{CléFichier{2016, dataset.FichierLieux}, "2016/lieux_2016.csv", "2016"},
+		{CléFichier{2017, dataset.FichierLieux}, "2017/lieux-2017.csv", "2017"},
+	} {
+		ressource, ok := ressources[expected.clé]
+
+		if !ok {
+			t.Fatalf("no resource for %v", expected.clé)
+		}
+
+		destPath := dataset.FilePath(dataPath, expected.clé.Fichier, expected.clé.Year)
+
+		if destPath != filepath.Join(dataPath, filepath.FromSlash(expected.chemin)) {
+			t.Errorf("expected %v to be saved as %v, got %v", ressource.Url, expected.chemin, destPath)
+		}
+
+		written, err := client.Download(ressource, destPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contenu, err := os.ReadFile(destPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(contenu) != expected.contenu || written != int64(len(expected.contenu)) {
+			t.Errorf("expected %v to contain %q, got %q (%v bytes)", destPath, expected.contenu, contenu, written)
+		}
+	}
+}
+
+func TestLatestDuplicateWins(t *testing.T) {
+	standIn := newStandIn(t, map[string]string{
+		"a/usagers-2019.csv": "old",
+		"b/usagers-2019.csv": "new",
+		"c/usagers-2019.csv": "older",
+	})
+
+	standIn.dates["a/usagers-2019.csv"] = "2020-09-01T10:00:00"
+	standIn.dates["b/usagers-2019.csv"] = "2021-03-15T08:30:00"
+	standIn.dates["c/usagers-2019.csv"] = "2019-11-30T00:00:00"
+	jeuDeDonnées, err := standIn.client().GetJeuDeDonnées("accidents")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ressources, doublons := jeuDeDonnées.ResourcesByFile()
+	ressource := ressources[CléFichier{2019, dataset.FichierUsagers}]
+
+	if !strings.HasSuffix(ressource.Url, "/b/usagers-2019.csv") {
+		t.Errorf("expected the latest resource to be used, got %v", ressource.Url)
+	}
+
+	if len(doublons) != 2 {
+		t.Errorf("expected 2 duplicates to be reported, got %v", doublons)
+	}
+}
+
+func TestSizeMismatchRemovesTempFile(t *testing.T) {
+	standIn := newStandIn(t, map[string]string{"vehicules-2020.csv": "truncated"})
+	standIn.tailles["vehicules-2020.csv"] = 1000
+	client := standIn.client()
+	jeuDeDonnées, err := client.GetJeuDeDonnées("accidents")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataPath := t.TempDir()
+	destPath := dataset.FilePath(dataPath, dataset.FichierVéhicules, 2020)
+
+	if _, err := client.Download(jeuDeDonnées.Ressources[0], destPath); err == nil {
+		t.Fatal("expected an error for a file with the wrong size")
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(destPath))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("expected no files after a failed download, found %v", entries)
+	}
+}
+
+func TestNon200Response(t *testing.T) {
+	standIn := newStandIn(t, nil)
+	client := standIn.client()
+
+	if _, err := client.GetJeuDeDonnées("unknown"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a 404 error for an unknown dataset, got %v", err)
+	}
+
+	destPath := filepath.Join(t.TempDir(), "2019", "lieux-2019.csv")
+	ressource := Ressource{Url: standIn.server.URL + "/files/lieux-2019.csv"}
+
+	if _, err := client.Download(ressource, destPath); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("expected a 500 error for a missing file, got %v", err)
+	}
+
+	if _, err := os.Stat(destPath); !os.IsNotExist(err) {
+		t.Errorf("expected %v not to exist after a failed download", destPath)
+	}
+}
@@ -1,6 +1,9 @@
 package dataset
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 type Jsonable interface {
 	AsJson() (string, error)
@@ -36,6 +39,7 @@ const (
 	Conducteur CatégorieUsager = iota
 	Passager
 	Piéton
+	PiétonEnRollerOuTrottinette // Only before 2018
 )
 
 func (catégorieUsager CatégorieUsager) String() string {
@@ -43,6 +47,7 @@ func (catégorieUsager CatégorieUsager) String() string {
 		"Conducteur",
 		"Passager",
 		"Piéton",
+		"Piéton en roller ou en trottinette",
 	}[catégorieUsager]
 }
 
@@ -99,6 +104,10 @@ type CatégorieVéhicule int
 const (
 	CatégorieVéhiculeIndéterminable CatégorieVéhicule = iota
 	Bicyclette
+	VéloÀAssistanceÉlectrique
+	EDPÀMoteur
+	EDPSansMoteur
+	EDPNonPrécisé
 	Scooter
 	Motocyclette
 	VéhiculeLéger
@@ -115,6 +124,10 @@ func (catégorieVéhicule CatégorieVéhicule) String() string {
 	return [...]string{
 		"Indéterminable",
 		"Bicyclette",
+		"Vélo à assistance électrique",
+		"Engin de déplacement personnel à moteur",
+		"Engin de déplacement personnel sans moteur",
+		"Engin de déplacement personnel",
 		"Scooter",
 		"Motocyclette",
 		"Véhicule léger",
@@ -132,32 +145,802 @@ func (catégorieVéhicule CatégorieVéhicule) MarshalJSON() ([]byte, error) {
 	return json.Marshal(catégorieVéhicule.String())
 }
 
+type Luminosité int
+
+const (
+	LuminositéNonRenseignée Luminosité = iota
+	PleinJour
+	CrépusculeOuAube
+	NuitSansÉclairagePublic
+	NuitAvecÉclairagePublicNonAllumé
+	NuitAvecÉclairagePublicAllumé
+)
+
+func (luminosité Luminosité) String() string {
+	return [...]string{
+		"Non renseignée",
+		"Plein jour",
+		"Crépuscule ou aube",
+		"Nuit sans éclairage public",
+		"Nuit avec éclairage public non allumé",
+		"Nuit avec éclairage public allumé",
+	}[luminosité]
+}
+
+func (luminosité Luminosité) MarshalJSON() ([]byte, error) {
+	return json.Marshal(luminosité.String())
+}
+
+type Agglomération int
+
+const (
+	AgglomérationNonRenseignée Agglomération = iota
+	HorsAgglomération
+	EnAgglomération
+)
+
+func (agglomération Agglomération) String() string {
+	return [...]string{
+		"Non renseignée",
+		"Hors agglomération",
+		"En agglomération",
+	}[agglomération]
+}
+
+func (agglomération Agglomération) MarshalJSON() ([]byte, error) {
+	return json.Marshal(agglomération.String())
+}
+
+type Intersection int
+
+const (
+	IntersectionNonRenseignée Intersection = iota
+	HorsIntersection
+	IntersectionEnX
+	IntersectionEnT
+	IntersectionEnY
+	IntersectionÀPlusDe4Branches
+	IntersectionGiratoire
+	IntersectionPlace
+	IntersectionPassageÀNiveau
+	AutreIntersection
+)
+
+func (intersection Intersection) String() string {
+	return [...]string{
+		"Non renseignée",
+		"Hors intersection",
+		"Intersection en X",
+		"Intersection en T",
+		"Intersection en Y",
+		"Intersection à plus de 4 branches",
+		"Giratoire",
+		"Place",
+		"Passage à niveau",
+		"Autre intersection",
+	}[intersection]
+}
+
+func (intersection Intersection) MarshalJSON() ([]byte, error) {
+	return json.Marshal(intersection.String())
+}
+
+type ConditionsAtmosphériques int
+
+const (
+	ConditionsAtmosphériquesNonRenseignées ConditionsAtmosphériques = iota
+	ConditionsAtmosphériquesNormales
+	PluieLégère
+	PluieForte
+	NeigeGrêle
+	BrouillardFumée
+	VentFortTempête
+	TempsÉblouissant
+	TempsCouvert
+	AutresConditionsAtmosphériques
+)
+
+func (conditionsAtmosphériques ConditionsAtmosphériques) String() string {
+	return [...]string{
+		"Non renseignées",
+		"Normales",
+		"Pluie légère",
+		"Pluie forte",
+		"Neige - grêle",
+		"Brouillard - fumée",
+		"Vent fort - tempête",
+		"Temps éblouissant",
+		"Temps couvert",
+		"Autre",
+	}[conditionsAtmosphériques]
+}
+
+func (conditionsAtmosphériques ConditionsAtmosphériques) MarshalJSON() ([]byte, error) {
+	return json.Marshal(conditionsAtmosphériques.String())
+}
+
+type TypeCollision int
+
+const (
+	TypeCollisionNonRenseigné TypeCollision = iota
+	DeuxVéhiculesFrontale
+	DeuxVéhiculesParLArrière
+	DeuxVéhiculesParLeCôté
+	TroisVéhiculesEtPlusEnChaîne
+	TroisVéhiculesEtPlusCollisionsMultiples
+	AutreCollision
+	SansCollision
+)
+
+func (typeCollision TypeCollision) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Deux véhicules - frontale",
+		"Deux véhicules - par l'arrière",
+		"Deux véhicules - par le côté",
+		"Trois véhicules et plus - en chaîne",
+		"Trois véhicules et plus - collisions multiples",
+		"Autre collision",
+		"Sans collision",
+	}[typeCollision]
+}
+
+func (typeCollision TypeCollision) MarshalJSON() ([]byte, error) {
+	return json.Marshal(typeCollision.String())
+}
+
+type CatégorieRoute int
+
+const (
+	CatégorieRouteNonRenseignée CatégorieRoute = iota
+	Autoroute
+	RouteNationale
+	RouteDépartementale
+	VoieCommunale
+	HorsRéseauPublic
+	ParcDeStationnement
+	RouteDeMétropoleUrbaine
+	AutreRoute
+)
+
+func (catégorieRoute CatégorieRoute) String() string {
+	return [...]string{
+		"Non renseignée",
+		"Autoroute",
+		"Route nationale",
+		"Route départementale",
+		"Voie communale",
+		"Hors réseau public",
+		"Parc de stationnement ouvert à la circulation publique",
+		"Route de métropole urbaine",
+		"Autre",
+	}[catégorieRoute]
+}
+
+func (catégorieRoute CatégorieRoute) MarshalJSON() ([]byte, error) {
+	return json.Marshal(catégorieRoute.String())
+}
+
+type RégimeCirculation int
+
+const (
+	RégimeCirculationNonRenseigné RégimeCirculation = iota
+	SensUnique
+	Bidirectionnelle
+	ChausséesSéparées
+	VoiesDAffectationVariable
+)
+
+func (régimeCirculation RégimeCirculation) String() string {
+	return [...]string{
+		"Non renseigné",
+		"À sens unique",
+		"Bidirectionnelle",
+		"À chaussées séparées",
+		"Avec voies d'affectation variable",
+	}[régimeCirculation]
+}
+
+func (régimeCirculation RégimeCirculation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(régimeCirculation.String())
+}
+
+type Profil int
+
+const (
+	ProfilNonRenseigné Profil = iota
+	Plat
+	Pente
+	SommetDeCôte
+	BasDeCôte
+)
+
+func (profil Profil) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Plat",
+		"Pente",
+		"Sommet de côte",
+		"Bas de côte",
+	}[profil]
+}
+
+func (profil Profil) MarshalJSON() ([]byte, error) {
+	return json.Marshal(profil.String())
+}
+
+type TracéEnPlan int
+
+const (
+	TracéEnPlanNonRenseigné TracéEnPlan = iota
+	PartieRectiligne
+	CourbeÀGauche
+	CourbeÀDroite
+	EnS
+)
+
+func (tracéEnPlan TracéEnPlan) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Partie rectiligne",
+		"En courbe à gauche",
+		"En courbe à droite",
+		"En « S »",
+	}[tracéEnPlan]
+}
+
+func (tracéEnPlan TracéEnPlan) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tracéEnPlan.String())
+}
+
+type ÉtatSurface int
+
+const (
+	ÉtatSurfaceNonRenseigné ÉtatSurface = iota
+	SurfaceNormale
+	Mouillée
+	Flaques
+	Inondée
+	Enneigée
+	Boue
+	Verglacée
+	CorpsGras
+	AutreÉtatSurface
+)
+
+func (étatSurface ÉtatSurface) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Normale",
+		"Mouillée",
+		"Flaques",
+		"Inondée",
+		"Enneigée",
+		"Boue",
+		"Verglacée",
+		"Corps gras - huile",
+		"Autre",
+	}[étatSurface]
+}
+
+func (étatSurface ÉtatSurface) MarshalJSON() ([]byte, error) {
+	return json.Marshal(étatSurface.String())
+}
+
+type Aménagement int
+
+const (
+	AménagementNonRenseigné Aménagement = iota
+	AucunAménagement
+	Souterrain
+	Pont
+	Bretelle
+	VoieFerrée
+	CarrefourAménagé
+	ZonePiétonne
+	ZoneDePéage
+	Chantier
+	AutreAménagement
+)
+
+func (aménagement Aménagement) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Aucun",
+		"Souterrain - tunnel",
+		"Pont - autopont",
+		"Bretelle d'échangeur ou de raccordement",
+		"Voie ferrée",
+		"Carrefour aménagé",
+		"Zone piétonne",
+		"Zone de péage",
+		"Chantier",
+		"Autre",
+	}[aménagement]
+}
+
+func (aménagement Aménagement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(aménagement.String())
+}
+
+type Situation int
+
+const (
+	SituationNonRenseignée Situation = iota
+	AucuneSituation
+	SurChaussée
+	SurBandeDArrêtDUrgence
+	SurAccotement
+	SurTrottoir
+	SurPisteCyclable
+	SurAutreVoieSpéciale
+	AutreSituation
+)
+
+func (situation Situation) String() string {
+	return [...]string{
+		"Non renseignée",
+		"Aucune",
+		"Sur chaussée",
+		"Sur bande d'arrêt d'urgence",
+		"Sur accotement",
+		"Sur trottoir",
+		"Sur piste cyclable",
+		"Sur autre voie spéciale",
+		"Autre",
+	}[situation]
+}
+
+func (situation Situation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(situation.String())
+}
+
+// A number that may not have been specified, in which case it is 0.
+type Nombre int
+
+func (nombre Nombre) String() string {
+	if nombre == 0 {
+		return ""
+	} else {
+		return fmt.Sprint(int(nombre))
+	}
+}
+
+func (nombre Nombre) MarshalJSON() ([]byte, error) {
+	if nombre == 0 {
+		return json.Marshal(nil)
+	} else {
+		return json.Marshal(int(nombre))
+	}
+}
+
 type Lieu struct {
-	IdAccident   string
-	VoieSpéciale VoieSpéciale
+	IdAccident        string
+	CatégorieRoute    CatégorieRoute
+	RégimeCirculation RégimeCirculation
+	NombreVoies       Nombre
+	VoieSpéciale      VoieSpéciale
+	Profil            Profil
+	TracéEnPlan       TracéEnPlan
+	ÉtatSurface       ÉtatSurface
+	Aménagement       Aménagement
+	Situation         Situation
+	VitesseMaximale   Nombre // Only available from 2019
+	CodesOfficiels    CodesOfficiels
 }
 
 func (lieu Lieu) AsJson() (string, error) {
 	return ToJson(lieu)
 }
 
+type UtilisationÉquipement int
+
+const (
+	UtilisationNonRenseignée UtilisationÉquipement = iota
+	Utilisé
+	NonUtilisé
+	UtilisationNonDéterminable
+)
+
+func (utilisationÉquipement UtilisationÉquipement) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Oui",
+		"Non",
+		"Non déterminable",
+	}[utilisationÉquipement]
+}
+
+func (utilisationÉquipement UtilisationÉquipement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(utilisationÉquipement.String())
+}
+
+// Whether each type of safety equipment was used. Before 2019, the data only
+// describes one type of equipment per user, so the others are not specified.
+type Équipements struct {
+	Ceinture                UtilisationÉquipement
+	Casque                  UtilisationÉquipement
+	DispositifEnfants       UtilisationÉquipement
+	ÉquipementRéfléchissant UtilisationÉquipement
+	Airbag                  UtilisationÉquipement // Only for motorised two- or three-wheelers, from 2019
+	Gants                   UtilisationÉquipement // Only for motorised two- or three-wheelers, from 2019
+	AutreÉquipement         UtilisationÉquipement
+}
+
+// Returns a set of equipment in which every type has the same status.
+func allÉquipements(utilisationÉquipement UtilisationÉquipement) Équipements {
+	return Équipements{
+		Ceinture:                utilisationÉquipement,
+		Casque:                  utilisationÉquipement,
+		DispositifEnfants:       utilisationÉquipement,
+		ÉquipementRéfléchissant: utilisationÉquipement,
+		Airbag:                  utilisationÉquipement,
+		Gants:                   utilisationÉquipement,
+		AutreÉquipement:         utilisationÉquipement,
+	}
+}
+
+type LocalisationPiéton int
+
+const (
+	LocalisationPiétonNonRenseignée LocalisationPiéton = iota
+	LocalisationPiétonSansObjet
+	PiétonSurChausséeÀPlusDe50M
+	PiétonSurChausséeÀMoinsDe50M
+	PiétonSurPassageSansSignalisation
+	PiétonSurPassageAvecSignalisation
+	PiétonSurTrottoir
+	PiétonSurAccotement
+	PiétonSurRefugeOuBAU
+	PiétonSurContreAllée
+	LocalisationPiétonInconnue
+)
+
+func (localisationPiéton LocalisationPiéton) String() string {
+	return [...]string{
+		"Non renseignée",
+		"Sans objet",
+		"Sur chaussée à plus de 50 m du passage piéton",
+		"Sur chaussée à moins de 50 m du passage piéton",
+		"Sur passage piéton sans signalisation lumineuse",
+		"Sur passage piéton avec signalisation lumineuse",
+		"Sur trottoir",
+		"Sur accotement",
+		"Sur refuge ou BAU",
+		"Sur contre-allée",
+		"Inconnue",
+	}[localisationPiéton]
+}
+
+func (localisationPiéton LocalisationPiéton) MarshalJSON() ([]byte, error) {
+	return json.Marshal(localisationPiéton.String())
+}
+
+type ActionPiéton int
+
+const (
+	ActionPiétonNonRenseignée ActionPiéton = iota
+	DansLeSensDuVéhiculeHeurtant
+	EnSensInverseDuVéhicule
+	PiétonTraversant
+	PiétonMasqué
+	PiétonJouantCourant
+	PiétonAvecAnimal
+	AutreActionPiéton
+	PiétonMontantDescendantDuVéhicule
+	ActionPiétonInconnue
+)
+
+func (actionPiéton ActionPiéton) String() string {
+	return [...]string{
+		"Non renseignée",
+		"Se déplaçant dans le sens du véhicule heurtant",
+		"Se déplaçant dans le sens inverse du véhicule",
+		"Traversant",
+		"Masqué",
+		"Jouant - courant",
+		"Avec un animal",
+		"Autre",
+		"Monte ou descend du véhicule",
+		"Inconnue",
+	}[actionPiéton]
+}
+
+func (actionPiéton ActionPiéton) MarshalJSON() ([]byte, error) {
+	return json.Marshal(actionPiéton.String())
+}
+
+type ÉtatPiéton int
+
+const (
+	ÉtatPiétonNonRenseigné ÉtatPiéton = iota
+	PiétonSeul
+	PiétonAccompagné
+	PiétonEnGroupe
+)
+
+func (étatPiéton ÉtatPiéton) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Seul",
+		"Accompagné",
+		"En groupe",
+	}[étatPiéton]
+}
+
+func (étatPiéton ÉtatPiéton) MarshalJSON() ([]byte, error) {
+	return json.Marshal(étatPiéton.String())
+}
+
+type MotifTrajet int
+
+const (
+	MotifTrajetNonRenseigné MotifTrajet = iota
+	DomicileTravail
+	DomicileÉcole
+	CoursesAchats
+	UtilisationProfessionnelle
+	PromenadeLoisirs
+	AutreTrajet
+)
+
+func (motifTrajet MotifTrajet) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Domicile - travail",
+		"Domicile - école",
+		"Courses - achats",
+		"Utilisation professionnelle",
+		"Promenade - loisirs",
+		"Autre",
+	}[motifTrajet]
+}
+
+func (motifTrajet MotifTrajet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(motifTrajet.String())
+}
+
 type Usager struct {
-	IdVéhicule      string
-	IdAccident      string
-	CatégorieUsager CatégorieUsager
-	Gravité         Gravité
-	Sexe            Sexe
-	AnnéeNaissance  int
+	IdUsager           string // Only in the data from 2021, synthesised for earlier years
+	IdVéhicule         string
+	NumVéhicule        string // The letter identifying the vehicle within the accident
+	IdAccident         string
+	CatégorieUsager    CatégorieUsager
+	Gravité            Gravité
+	Sexe               Sexe
+	AnnéeNaissance     int
+	MotifTrajet        MotifTrajet
+	Place              Nombre // Position in the vehicle, see the diagrams in the official documentation
+	Équipements        Équipements
+	LocalisationPiéton LocalisationPiéton
+	ActionPiéton       ActionPiéton
+	ÉtatPiéton         ÉtatPiéton
+	CodesOfficiels     CodesOfficiels
 }
 
 func (usager Usager) AsJson() (string, error) {
 	return ToJson(usager)
 }
 
+type SensCirculation int
+
+const (
+	SensCirculationNonRenseigné SensCirculation = iota
+	SensCroissant
+	SensDécroissant
+	SensAbsenceDeRepère
+)
+
+func (sensCirculation SensCirculation) String() string {
+	return [...]string{
+		"Non renseigné",
+		"PK ou PR ou numéro d'adresse postale croissant",
+		"PK ou PR ou numéro d'adresse postale décroissant",
+		"Absence de repère",
+	}[sensCirculation]
+}
+
+func (sensCirculation SensCirculation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sensCirculation.String())
+}
+
+type ObstacleFixe int
+
+const (
+	ObstacleFixeNonRenseigné ObstacleFixe = iota
+	AucunObstacleFixe
+	ObstacleVéhiculeEnStationnement
+	ObstacleArbre
+	ObstacleGlissièreMétallique
+	ObstacleGlissièreBéton
+	ObstacleAutreGlissière
+	ObstacleBâtimentMurPileDePont
+	ObstacleSupportDeSignalisation
+	ObstaclePoteau
+	ObstacleMobilierUrbain
+	ObstacleParapet
+	ObstacleIlotRefugeBorneHaute
+	ObstacleBordureDeTrottoir
+	ObstacleFosséTalusParoiRocheuse
+	AutreObstacleFixeSurChaussée
+	AutreObstacleFixeSurTrottoirOuAccotement
+	SortieDeChausséeSansObstacle
+	ObstacleBuseTêteDAqueduc
+)
+
+func (obstacleFixe ObstacleFixe) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Sans objet",
+		"Véhicule en stationnement",
+		"Arbre",
+		"Glissière métallique",
+		"Glissière béton",
+		"Autre glissière",
+		"Bâtiment, mur, pile de pont",
+		"Support de signalisation verticale ou poste d'appel d'urgence",
+		"Poteau",
+		"Mobilier urbain",
+		"Parapet",
+		"Ilot, refuge, borne haute",
+		"Bordure de trottoir",
+		"Fossé, talus, paroi rocheuse",
+		"Autre obstacle fixe sur chaussée",
+		"Autre obstacle fixe sur trottoir ou accotement",
+		"Sortie de chaussée sans obstacle",
+		"Buse - tête d'aqueduc",
+	}[obstacleFixe]
+}
+
+func (obstacleFixe ObstacleFixe) MarshalJSON() ([]byte, error) {
+	return json.Marshal(obstacleFixe.String())
+}
+
+type ObstacleMobile int
+
+const (
+	ObstacleMobileNonRenseigné ObstacleMobile = iota
+	AucunObstacleMobile
+	ObstaclePiéton
+	ObstacleVéhicule
+	ObstacleVéhiculeSurRail
+	ObstacleAnimalDomestique
+	ObstacleAnimalSauvage
+	AutreObstacleMobile
+)
+
+func (obstacleMobile ObstacleMobile) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Aucun",
+		"Piéton",
+		"Véhicule",
+		"Véhicule sur rail",
+		"Animal domestique",
+		"Animal sauvage",
+		"Autre",
+	}[obstacleMobile]
+}
+
+func (obstacleMobile ObstacleMobile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(obstacleMobile.String())
+}
+
+type PointDeChoc int
+
+const (
+	PointDeChocNonRenseigné PointDeChoc = iota
+	AucunChoc
+	ChocAvant
+	ChocAvantDroit
+	ChocAvantGauche
+	ChocArrière
+	ChocArrièreDroit
+	ChocArrièreGauche
+	ChocCôtéDroit
+	ChocCôtéGauche
+	ChocsMultiples
+)
+
+func (pointDeChoc PointDeChoc) String() string {
+	return [...]string{
+		"Non renseigné",
+		"Aucun",
+		"Avant",
+		"Avant droit",
+		"Avant gauche",
+		"Arrière",
+		"Arrière droit",
+		"Arrière gauche",
+		"Côté droit",
+		"Côté gauche",
+		"Chocs multiples (tonneaux)",
+	}[pointDeChoc]
+}
+
+func (pointDeChoc PointDeChoc) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pointDeChoc.String())
+}
+
+type Manœuvre int
+
+const (
+	ManœuvreNonRenseignée Manœuvre = iota
+	SansChangementDeDirection
+	MêmeSensMêmeFile
+	Entre2Files
+	EnMarcheArrière
+	ÀContresens
+	EnFranchissantLeTerrePleinCentral
+	DansLeCouloirBusMêmeSens
+	DansLeCouloirBusSensInverse
+	EnSInsérant
+	EnFaisantDemiTour
+	ChangeantDeFileÀGauche
+	ChangeantDeFileÀDroite
+	DéportéÀGauche
+	DéportéÀDroite
+	TournantÀGauche
+	TournantÀDroite
+	DépassantÀGauche
+	DépassantÀDroite
+	TraversantLaChaussée
+	ManœuvreDeStationnement
+	ManœuvreDÉvitement
+	OuvertureDePorte
+	ArrêtéHorsStationnement
+	EnStationnementAvecOccupants
+	CirculantSurTrottoir
+	AutreManœuvre
+)
+
+func (manœuvre Manœuvre) String() string {
+	return [...]string{
+		"Non renseignée",
+		"Sans changement de direction",
+		"Même sens, même file",
+		"Entre 2 files",
+		"En marche arrière",
+		"A contresens",
+		"En franchissant le terre-plein central",
+		"Dans le couloir bus, dans le même sens",
+		"Dans le couloir bus, dans le sens inverse",
+		"En s'insérant",
+		"En faisant demi-tour sur la chaussée",
+		"Changeant de file - A gauche",
+		"Changeant de file - A droite",
+		"Déporté - A gauche",
+		"Déporté - A droite",
+		"Tournant - A gauche",
+		"Tournant - A droite",
+		"Dépassant - A gauche",
+		"Dépassant - A droite",
+		"Traversant la chaussée",
+		"Manœuvre de stationnement",
+		"Manœuvre d'évitement",
+		"Ouverture de porte",
+		"Arrêté (hors stationnement)",
+		"En stationnement (avec occupants)",
+		"Circulant sur trottoir",
+		"Autres manœuvres",
+	}[manœuvre]
+}
+
+func (manœuvre Manœuvre) MarshalJSON() ([]byte, error) {
+	return json.Marshal(manœuvre.String())
+}
+
 type Véhicule struct {
 	IdVéhicule        string
+	NumVéhicule       string // The letter identifying the vehicle within the accident
 	IdAccident        string
 	CatégorieVéhicule CatégorieVéhicule
+	SensCirculation   SensCirculation
+	ObstacleFixe      ObstacleFixe
+	ObstacleMobile    ObstacleMobile
+	PointDeChoc       PointDeChoc
+	Manœuvre          Manœuvre
+	CodesOfficiels    CodesOfficiels
 	Usagers           []*Usager
 }
 
@@ -166,16 +949,22 @@ func (véhicule Véhicule) AsJson() (string, error) {
 }
 
 type Accident struct {
-	IdAccident    string
-	Date          string
-	Département   string
-	Commune       *int
-	Adresse       string
-	Latitude      string
-	Longitude     string
-	Lieu          *Lieu
-	Véhicules     []*Véhicule
-	AutresUsagers []*Usager // Users not associated with a vehicle
+	IdAccident               string
+	Date                     string
+	Département              string
+	Commune                  *int
+	Adresse                  string
+	Latitude                 string
+	Longitude                string
+	Luminosité               Luminosité
+	Agglomération            Agglomération
+	Intersection             Intersection
+	ConditionsAtmosphériques ConditionsAtmosphériques
+	TypeCollision            TypeCollision
+	CodesOfficiels           CodesOfficiels
+	Lieu                     *Lieu
+	Véhicules                []*Véhicule
+	AutresUsagers            []*Usager // Users not associated with a vehicle
 }
 
 func (accident Accident) AsJson() (string, error) {
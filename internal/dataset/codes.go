@@ -0,0 +1,907 @@
+package dataset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Reads a column containing a numeric code. An empty value is treated as -1,
+// which is the code used for "non renseigné" since 2019.
+func readCode(row map[string]string, columnName string, idAccident string, path string) (int, error) {
+	codeStr, err := readColumn(row, columnName, path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if codeStr == "" {
+		return -1, nil
+	}
+
+	codeInt, err := strconv.Atoi(codeStr)
+
+	if err != nil {
+		return 0, fmt.Errorf(
+			"can't parse column '%v' with value '%v' for accident %v in %v",
+			columnName,
+			codeStr,
+			idAccident,
+			path,
+		)
+	}
+
+	return codeInt, nil
+}
+
+// Reads a column containing a count or a quantity. Values that are missing or are not
+// positive integers (some files contain spreadsheet errors such as '#ERREUR') are
+// treated as unspecified.
+func readNombre(row map[string]string, columnName string, path string) (Nombre, error) {
+	nombreStr, err := readColumn(row, columnName, path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	nombreInt, err := strconv.Atoi(nombreStr)
+
+	if err != nil || nombreInt < 0 {
+		return 0, nil
+	}
+
+	return Nombre(nombreInt), nil
+}
+
+// The codes in the columns below have the same meaning in all years, except where
+// noted.
+
+func parseLuminosité(code int) Luminosité {
+	switch code {
+	// 1 – Plein jour
+	case 1:
+		return PleinJour
+
+	// 2 – Crépuscule ou aube
+	case 2:
+		return CrépusculeOuAube
+
+	// 3 – Nuit sans éclairage public
+	case 3:
+		return NuitSansÉclairagePublic
+
+	// 4 – Nuit avec éclairage public non allumé
+	case 4:
+		return NuitAvecÉclairagePublicNonAllumé
+
+	// 5 – Nuit avec éclairage public allumé
+	case 5:
+		return NuitAvecÉclairagePublicAllumé
+
+	default:
+		return LuminositéNonRenseignée
+	}
+}
+
+func parseAgglomération(code int) Agglomération {
+	switch code {
+	// 1 – Hors agglomération
+	case 1:
+		return HorsAgglomération
+
+	// 2 – En agglomération
+	case 2:
+		return EnAgglomération
+
+	default:
+		return AgglomérationNonRenseignée
+	}
+}
+
+func parseIntersection(code int) Intersection {
+	switch code {
+	// 1 – Hors intersection
+	case 1:
+		return HorsIntersection
+
+	// 2 – Intersection en X
+	case 2:
+		return IntersectionEnX
+
+	// 3 – Intersection en T
+	case 3:
+		return IntersectionEnT
+
+	// 4 – Intersection en Y
+	case 4:
+		return IntersectionEnY
+
+	// 5 – Intersection à plus de 4 branches
+	case 5:
+		return IntersectionÀPlusDe4Branches
+
+	// 6 – Giratoire
+	case 6:
+		return IntersectionGiratoire
+
+	// 7 – Place
+	case 7:
+		return IntersectionPlace
+
+	// 8 – Passage à niveau
+	case 8:
+		return IntersectionPassageÀNiveau
+
+	// 9 – Autre intersection
+	case 9:
+		return AutreIntersection
+
+	default:
+		// 0 is sometimes used for this before 2019
+		return IntersectionNonRenseignée
+	}
+}
+
+func parseConditionsAtmosphériques(code int) ConditionsAtmosphériques {
+	switch code {
+	// 1 – Normale
+	case 1:
+		return ConditionsAtmosphériquesNormales
+
+	// 2 – Pluie légère
+	case 2:
+		return PluieLégère
+
+	// 3 – Pluie forte
+	case 3:
+		return PluieForte
+
+	// 4 – Neige - grêle
+	case 4:
+		return NeigeGrêle
+
+	// 5 – Brouillard - fumée
+	case 5:
+		return BrouillardFumée
+
+	// 6 – Vent fort - tempête
+	case 6:
+		return VentFortTempête
+
+	// 7 – Temps éblouissant
+	case 7:
+		return TempsÉblouissant
+
+	// 8 – Temps couvert
+	case 8:
+		return TempsCouvert
+
+	// 9 – Autre
+	case 9:
+		return AutresConditionsAtmosphériques
+
+	default:
+		return ConditionsAtmosphériquesNonRenseignées
+	}
+}
+
+func parseTypeCollision(code int) TypeCollision {
+	switch code {
+	// 1 – Deux véhicules - frontale
+	case 1:
+		return DeuxVéhiculesFrontale
+
+	// 2 – Deux véhicules – par l’arrière
+	case 2:
+		return DeuxVéhiculesParLArrière
+
+	// 3 – Deux véhicules – par le coté
+	case 3:
+		return DeuxVéhiculesParLeCôté
+
+	// 4 – Trois véhicules et plus – en chaîne
+	case 4:
+		return TroisVéhiculesEtPlusEnChaîne
+
+	// 5 – Trois véhicules et plus - collisions multiples
+	case 5:
+		return TroisVéhiculesEtPlusCollisionsMultiples
+
+	// 6 – Autre collision
+	case 6:
+		return AutreCollision
+
+	// 7 – Sans collision
+	case 7:
+		return SansCollision
+
+	default:
+		return TypeCollisionNonRenseigné
+	}
+}
+
+func parseCatégorieRoute(code int) CatégorieRoute {
+	switch code {
+	// 1 – Autoroute
+	case 1:
+		return Autoroute
+
+	// 2 – Route nationale
+	case 2:
+		return RouteNationale
+
+	// 3 – Route Départementale
+	case 3:
+		return RouteDépartementale
+
+	// 4 – Voie Communale
+	case 4:
+		return VoieCommunale
+
+	// 5 – Hors réseau public
+	case 5:
+		return HorsRéseauPublic
+
+	// 6 – Parc de stationnement ouvert à la circulation publique
+	case 6:
+		return ParcDeStationnement
+
+	// 7 – Routes de métropole urbaine (from 2019)
+	case 7:
+		return RouteDeMétropoleUrbaine
+
+	// 9 – Autre
+	case 9:
+		return AutreRoute
+
+	default:
+		return CatégorieRouteNonRenseignée
+	}
+}
+
+func parseRégimeCirculation(code int) RégimeCirculation {
+	switch code {
+	// 1 – A sens unique
+	case 1:
+		return SensUnique
+
+	// 2 – Bidirectionnelle
+	case 2:
+		return Bidirectionnelle
+
+	// 3 – A chaussées séparées
+	case 3:
+		return ChausséesSéparées
+
+	// 4 – Avec voies d’affectation variable
+	case 4:
+		return VoiesDAffectationVariable
+
+	default:
+		// 0 is sometimes used for this before 2019
+		return RégimeCirculationNonRenseigné
+	}
+}
+
+func parseProfil(code int) Profil {
+	switch code {
+	// 1 – Plat
+	case 1:
+		return Plat
+
+	// 2 – Pente
+	case 2:
+		return Pente
+
+	// 3 – Sommet de côte
+	case 3:
+		return SommetDeCôte
+
+	// 4 – Bas de côte
+	case 4:
+		return BasDeCôte
+
+	default:
+		// 0 is sometimes used for this before 2019
+		return ProfilNonRenseigné
+	}
+}
+
+func parseTracéEnPlan(code int) TracéEnPlan {
+	switch code {
+	// 1 – Partie rectiligne
+	case 1:
+		return PartieRectiligne
+
+	// 2 – En courbe à gauche
+	case 2:
+		return CourbeÀGauche
+
+	// 3 – En courbe à droite
+	case 3:
+		return CourbeÀDroite
+
+	// 4 – En « S »
+	case 4:
+		return EnS
+
+	default:
+		// 0 is sometimes used for this before 2019
+		return TracéEnPlanNonRenseigné
+	}
+}
+
+func parseÉtatSurface(code int) ÉtatSurface {
+	switch code {
+	// 1 – Normale
+	case 1:
+		return SurfaceNormale
+
+	// 2 – Mouillée
+	case 2:
+		return Mouillée
+
+	// 3 – Flaques
+	case 3:
+		return Flaques
+
+	// 4 – Inondée
+	case 4:
+		return Inondée
+
+	// 5 – Enneigée
+	case 5:
+		return Enneigée
+
+	// 6 – Boue
+	case 6:
+		return Boue
+
+	// 7 – Verglacée
+	case 7:
+		return Verglacée
+
+	// 8 – Corps gras – huile
+	case 8:
+		return CorpsGras
+
+	// 9 – Autre
+	case 9:
+		return AutreÉtatSurface
+
+	default:
+		// 0 is sometimes used for this before 2019
+		return ÉtatSurfaceNonRenseigné
+	}
+}
+
+func parseAménagement(code int) Aménagement {
+	switch code {
+	// 0 – Aucun (from 2019; before then, YearDatasetReader1 reads 0 as -1)
+	case 0:
+		return AucunAménagement
+
+	// 1 – Souterrain - tunnel
+	case 1:
+		return Souterrain
+
+	// 2 – Pont - autopont
+	case 2:
+		return Pont
+
+	// 3 – Bretelle d’échangeur ou de raccordement
+	case 3:
+		return Bretelle
+
+	// 4 – Voie ferrée
+	case 4:
+		return VoieFerrée
+
+	// 5 – Carrefour aménagé
+	case 5:
+		return CarrefourAménagé
+
+	// 6 – Zone piétonne
+	case 6:
+		return ZonePiétonne
+
+	// 7 – Zone de péage
+	case 7:
+		return ZoneDePéage
+
+	// 8 – Chantier (from 2019)
+	case 8:
+		return Chantier
+
+	// 9 – Autres
+	case 9:
+		return AutreAménagement
+
+	default:
+		return AménagementNonRenseigné
+	}
+}
+
+func parseSituation(code int) Situation {
+	switch code {
+	// 0 – Aucun (from 2019; before then, YearDatasetReader1 reads 0 as -1)
+	case 0:
+		return AucuneSituation
+
+	// 1 – Sur chaussée
+	case 1:
+		return SurChaussée
+
+	// 2 – Sur bande d’arrêt d’urgence
+	case 2:
+		return SurBandeDArrêtDUrgence
+
+	// 3 – Sur accotement
+	case 3:
+		return SurAccotement
+
+	// 4 – Sur trottoir
+	case 4:
+		return SurTrottoir
+
+	// 5 – Sur piste cyclable
+	case 5:
+		return SurPisteCyclable
+
+	// 6 – Sur autre voie spéciale (from 2019)
+	case 6:
+		return SurAutreVoieSpéciale
+
+	// 8 – Autres (from 2019)
+	case 8:
+		return AutreSituation
+
+	default:
+		return SituationNonRenseignée
+	}
+}
+
+func parseLocalisationPiéton(code int) LocalisationPiéton {
+	switch code {
+	// 0 – Sans objet
+	case 0:
+		return LocalisationPiétonSansObjet
+
+	// 1 – Sur chaussée à + 50 m du passage piéton
+	case 1:
+		return PiétonSurChausséeÀPlusDe50M
+
+	// 2 – Sur chaussée à – 50 m du passage piéton
+	case 2:
+		return PiétonSurChausséeÀMoinsDe50M
+
+	// 3 – Sur passage piéton sans signalisation lumineuse
+	case 3:
+		return PiétonSurPassageSansSignalisation
+
+	// 4 – Sur passage piéton avec signalisation lumineuse
+	case 4:
+		return PiétonSurPassageAvecSignalisation
+
+	// 5 – Sur trottoir
+	case 5:
+		return PiétonSurTrottoir
+
+	// 6 – Sur accotement
+	case 6:
+		return PiétonSurAccotement
+
+	// 7 – Sur refuge ou BAU
+	case 7:
+		return PiétonSurRefugeOuBAU
+
+	// 8 – Sur contre allée
+	case 8:
+		return PiétonSurContreAllée
+
+	// 9 – Inconnue (from 2019)
+	case 9:
+		return LocalisationPiétonInconnue
+
+	default:
+		return LocalisationPiétonNonRenseignée
+	}
+}
+
+// From 2019, the 'actp' column can also contain the letters 'A' and 'B'.
+func parseActionPiéton(codeStr string) ActionPiéton {
+	switch strings.ToUpper(codeStr) {
+	// 1 – Sens véhicule heurtant
+	case "1":
+		return DansLeSensDuVéhiculeHeurtant
+
+	// 2 – Sens inverse du véhicule
+	case "2":
+		return EnSensInverseDuVéhicule
+
+	// 3 – Traversant
+	case "3":
+		return PiétonTraversant
+
+	// 4 – Masqué
+	case "4":
+		return PiétonMasqué
+
+	// 5 – Jouant – courant
+	case "5":
+		return PiétonJouantCourant
+
+	// 6 – Avec animal
+	case "6":
+		return PiétonAvecAnimal
+
+	// 9 – Autre
+	case "9":
+		return AutreActionPiéton
+
+	// A – Monte/descend du véhicule (from 2019)
+	case "A":
+		return PiétonMontantDescendantDuVéhicule
+
+	// B – Inconnue (from 2019)
+	case "B":
+		return ActionPiétonInconnue
+
+	default:
+		// 0 means 'Non renseigné ou sans objet'
+		return ActionPiétonNonRenseignée
+	}
+}
+
+func parseÉtatPiéton(code int) ÉtatPiéton {
+	switch code {
+	// 1 – Seul
+	case 1:
+		return PiétonSeul
+
+	// 2 – Accompagné
+	case 2:
+		return PiétonAccompagné
+
+	// 3 – En groupe
+	case 3:
+		return PiétonEnGroupe
+
+	default:
+		return ÉtatPiétonNonRenseigné
+	}
+}
+
+func parseSensCirculation(code int) SensCirculation {
+	switch code {
+	// 1 – PK ou PR ou numéro d’adresse postale croissant
+	case 1:
+		return SensCroissant
+
+	// 2 – PK ou PR ou numéro d’adresse postale décroissant
+	case 2:
+		return SensDécroissant
+
+	// 3 – Absence de repère (from 2019)
+	case 3:
+		return SensAbsenceDeRepère
+
+	default:
+		// 0 means 'Inconnu'
+		return SensCirculationNonRenseigné
+	}
+}
+
+func parseObstacleFixe(code int) ObstacleFixe {
+	switch code {
+	// 0 – Sans objet
+	case 0:
+		return AucunObstacleFixe
+
+	// 1 – Véhicule en stationnement
+	case 1:
+		return ObstacleVéhiculeEnStationnement
+
+	// 2 – Arbre
+	case 2:
+		return ObstacleArbre
+
+	// 3 – Glissière métallique
+	case 3:
+		return ObstacleGlissièreMétallique
+
+	// 4 – Glissière béton
+	case 4:
+		return ObstacleGlissièreBéton
+
+	// 5 – Autre glissière
+	case 5:
+		return ObstacleAutreGlissière
+
+	// 6 – Bâtiment, mur, pile de pont
+	case 6:
+		return ObstacleBâtimentMurPileDePont
+
+	// 7 – Support de signalisation verticale ou poste d’appel d’urgence
+	case 7:
+		return ObstacleSupportDeSignalisation
+
+	// 8 – Poteau
+	case 8:
+		return ObstaclePoteau
+
+	// 9 – Mobilier urbain
+	case 9:
+		return ObstacleMobilierUrbain
+
+	// 10 – Parapet
+	case 10:
+		return ObstacleParapet
+
+	// 11 – Ilot, refuge, borne haute
+	case 11:
+		return ObstacleIlotRefugeBorneHaute
+
+	// 12 – Bordure de trottoir
+	case 12:
+		return ObstacleBordureDeTrottoir
+
+	// 13 – Fossé, talus, paroi rocheuse
+	case 13:
+		return ObstacleFosséTalusParoiRocheuse
+
+	// 14 – Autre obstacle fixe sur chaussée
+	case 14:
+		return AutreObstacleFixeSurChaussée
+
+	// 15 – Autre obstacle fixe sur trottoir ou accotement
+	case 15:
+		return AutreObstacleFixeSurTrottoirOuAccotement
+
+	// 16 – Sortie de chaussée sans obstacle
+	case 16:
+		return SortieDeChausséeSansObstacle
+
+	// 17 – Buse – tête d’aqueduc (from 2019)
+	case 17:
+		return ObstacleBuseTêteDAqueduc
+
+	default:
+		return ObstacleFixeNonRenseigné
+	}
+}
+
+func parseObstacleMobile(code int) ObstacleMobile {
+	switch code {
+	// 0 – Aucun
+	case 0:
+		return AucunObstacleMobile
+
+	// 1 – Piéton
+	case 1:
+		return ObstaclePiéton
+
+	// 2 – Véhicule
+	case 2:
+		return ObstacleVéhicule
+
+	// 4 – Véhicule sur rail
+	case 4:
+		return ObstacleVéhiculeSurRail
+
+	// 5 – Animal domestique
+	case 5:
+		return ObstacleAnimalDomestique
+
+	// 6 – Animal sauvage
+	case 6:
+		return ObstacleAnimalSauvage
+
+	// 9 – Autre
+	case 9:
+		return AutreObstacleMobile
+
+	default:
+		return ObstacleMobileNonRenseigné
+	}
+}
+
+func parsePointDeChoc(code int) PointDeChoc {
+	switch code {
+	// 0 – Aucun
+	case 0:
+		return AucunChoc
+
+	// 1 – Avant
+	case 1:
+		return ChocAvant
+
+	// 2 – Avant droit
+	case 2:
+		return ChocAvantDroit
+
+	// 3 – Avant gauche
+	case 3:
+		return ChocAvantGauche
+
+	// 4 – Arrière
+	case 4:
+		return ChocArrière
+
+	// 5 – Arrière droit
+	case 5:
+		return ChocArrièreDroit
+
+	// 6 – Arrière gauche
+	case 6:
+		return ChocArrièreGauche
+
+	// 7 – Côté droit
+	case 7:
+		return ChocCôtéDroit
+
+	// 8 – Côté gauche
+	case 8:
+		return ChocCôtéGauche
+
+	// 9 – Chocs multiples (tonneaux)
+	case 9:
+		return ChocsMultiples
+
+	default:
+		return PointDeChocNonRenseigné
+	}
+}
+
+func parseManœuvre(code int) Manœuvre {
+	switch code {
+	// 1 – Sans changement de direction
+	case 1:
+		return SansChangementDeDirection
+
+	// 2 – Même sens, même file
+	case 2:
+		return MêmeSensMêmeFile
+
+	// 3 – Entre 2 files
+	case 3:
+		return Entre2Files
+
+	// 4 – En marche arrière
+	case 4:
+		return EnMarcheArrière
+
+	// 5 – A contresens
+	case 5:
+		return ÀContresens
+
+	// 6 – En franchissant le terre-plein central
+	case 6:
+		return EnFranchissantLeTerrePleinCentral
+
+	// 7 – Dans le couloir bus, dans le même sens
+	case 7:
+		return DansLeCouloirBusMêmeSens
+
+	// 8 – Dans le couloir bus, dans le sens inverse
+	case 8:
+		return DansLeCouloirBusSensInverse
+
+	// 9 – En s’insérant
+	case 9:
+		return EnSInsérant
+
+	// 10 – En faisant demi-tour sur la chaussée
+	case 10:
+		return EnFaisantDemiTour
+
+	// 11 – Changeant de file – A gauche
+	case 11:
+		return ChangeantDeFileÀGauche
+
+	// 12 – Changeant de file – A droite
+	case 12:
+		return ChangeantDeFileÀDroite
+
+	// 13 – Déporté – A gauche
+	case 13:
+		return DéportéÀGauche
+
+	// 14 – Déporté – A droite
+	case 14:
+		return DéportéÀDroite
+
+	// 15 – Tournant – A gauche
+	case 15:
+		return TournantÀGauche
+
+	// 16 – Tournant – A droite
+	case 16:
+		return TournantÀDroite
+
+	// 17 – Dépassant – A gauche
+	case 17:
+		return DépassantÀGauche
+
+	// 18 – Dépassant – A droite
+	case 18:
+		return DépassantÀDroite
+
+	// 19 – Traversant la chaussée
+	case 19:
+		return TraversantLaChaussée
+
+	// 20 – Manœuvre de stationnement
+	case 20:
+		return ManœuvreDeStationnement
+
+	// 21 – Manœuvre d’évitement
+	case 21:
+		return ManœuvreDÉvitement
+
+	// 22 – Ouverture de porte
+	case 22:
+		return OuvertureDePorte
+
+	// 23 – Arrêté (hors stationnement)
+	case 23:
+		return ArrêtéHorsStationnement
+
+	// 24 – En stationnement (avec occupants)
+	case 24:
+		return EnStationnementAvecOccupants
+
+	// 25 – Circulant sur trottoir (from 2019)
+	case 25:
+		return CirculantSurTrottoir
+
+	// 26 – Autres manœuvres (from 2019)
+	case 26:
+		return AutreManœuvre
+
+	default:
+		// 0 means 'Inconnue'
+		return ManœuvreNonRenseignée
+	}
+}
+
+func parseMotifTrajet(code int) MotifTrajet {
+	switch code {
+	// 1 – Domicile – travail
+	case 1:
+		return DomicileTravail
+
+	// 2 – Domicile – école
+	case 2:
+		return DomicileÉcole
+
+	// 3 – Courses – achats
+	case 3:
+		return CoursesAchats
+
+	// 4 – Utilisation professionnelle
+	case 4:
+		return UtilisationProfessionnelle
+
+	// 5 – Promenade – loisirs
+	case 5:
+		return PromenadeLoisirs
+
+	// 9 – Autre
+	case 9:
+		return AutreTrajet
+
+	default:
+		// 0 is also used for this
+		return MotifTrajetNonRenseigné
+	}
+}
+
+func parsePlace(place Nombre) Nombre {
+	// 10 – Piéton (non applicable)
+	if place == 10 {
+		return 0
+	} else {
+		return place
+	}
+}
@@ -0,0 +1,129 @@
+package dataset
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Columns that only one of the four files has. A file is recognised as one of
+// them if its header contains all of that file's columns.
+var signaturesDeFichiers = map[Fichier][]string{
+	FichierCaractéristiques: {"lum", "agg", "atm"},
+	FichierLieux:            {"catr", "circ", "surf"},
+	FichierVéhicules:        {"catv", "manv"},
+	FichierUsagers:          {"catu", "grav"},
+}
+
+// The files found in the directory for a year by looking at their headers.
+type découverte struct {
+	emplacements map[Fichier]Emplacement
+
+	// Descriptions of the cases where more than one file looked like the same one.
+	ambiguïtés []string
+}
+
+// Looks at the header of each file in <dataPath>/<year>/ to find out which of the
+// four files it is, regardless of its name.
+func discoverFiles(dataPath string, year uint) (découverte, error) {
+	résultat := découverte{emplacements: make(map[Fichier]Emplacement)}
+	yearPath := filepath.Join(dataPath, fmt.Sprint(year))
+	entries, err := os.ReadDir(yearPath)
+
+	if os.IsNotExist(err) {
+		return résultat, nil
+	} else if err != nil {
+		return résultat, err
+	}
+
+	candidats := make(map[Fichier][]Emplacement)
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		emplacement := Emplacement{Chemin: filepath.Join(yearPath, entry.Name())}
+		header, err := readHeader(emplacement)
+
+		if err != nil {
+			return résultat, err
+		}
+
+		for _, fichier := range Fichiers {
+			if containsAll(header, signaturesDeFichiers[fichier]) {
+				candidats[fichier] = append(candidats[fichier], emplacement)
+			}
+		}
+	}
+
+	for _, fichier := range Fichiers {
+		emplacements := candidats[fichier]
+
+		if len(emplacements) == 1 {
+			résultat.emplacements[fichier] = emplacements[0]
+		} else if len(emplacements) > 1 {
+			var chemins []string
+
+			for _, emplacement := range emplacements {
+				chemins = append(chemins, filepath.Base(emplacement.Chemin))
+			}
+
+			résultat.ambiguïtés = append(résultat.ambiguïtés, fmt.Sprintf(
+				"in %v, %v all look like the %v file, so it will be chosen by name",
+				yearPath, strings.Join(chemins, ", "), fichier,
+			))
+		}
+	}
+
+	return résultat, nil
+}
+
+// Returns the lower-case column names in the first line of a file, or nothing if
+// the file doesn't look like a CSV file.
+func readHeader(emplacement Emplacement) ([]string, error) {
+	file, err := emplacement.Open()
+
+	if err != nil {
+		// A file that claims to be compressed but isn't can't be a data file.
+		if strings.HasSuffix(strings.ToLower(emplacement.Chemin), ".gz") {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	defer file.Close()
+
+	line, err := bufio.NewReader(io.LimitReader(file, 64*1024)).ReadString('\n')
+
+	if err != nil && err != io.EOF {
+		return nil, nil
+	}
+
+	line = strings.TrimPrefix(line, "\uFEFF")
+
+	fields := strings.FieldsFunc(strings.ToLower(line), func(r rune) bool {
+		return r == ',' || r == ';' || r == '\t' || r == '\r' || r == '\n'
+	})
+
+	for index, field := range fields {
+		fields[index] = strings.Trim(strings.TrimSpace(field), `"`)
+	}
+
+	return fields, nil
+}
+
+func containsAll(slice []string, values []string) bool {
+	for _, value := range values {
+		if !slices.Contains(slice, value) {
+			return false
+		}
+	}
+
+	return true
+}
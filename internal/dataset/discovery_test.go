@@ -0,0 +1,102 @@
+package dataset
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSignatures(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		enTête  string
+		fichier Fichier
+		reconnu bool
+	}{
+		{"caracteristiques 2005-2018", "Num_Acc,an,mois,jour,hrmn,lum,agg,int,atm,col,com,adr,gps,lat,long,dep\n", FichierCaractéristiques, true},
+		{"caracteristiques 2019-2022", enTêteCaractéristiques, FichierCaractéristiques, true},
+		{"caract 2023", "Accident_Id;jour;mois;an;hrmn;lum;dep;com;agg;int;atm;col;adr;lat;long\n", FichierCaractéristiques, true},
+		{"lieux 2005-2018", "Num_Acc,catr,voie,v1,v2,circ,nbv,pr,pr1,vosp,prof,plan,lartpc,larrout,surf,infra,situ,env1\n", FichierLieux, true},
+		{"lieux 2019-2023", enTêteLieux, FichierLieux, true},
+		{"vehicules 2005-2018", "Num_Acc,senc,catv,occutc,obs,obsm,choc,manv,num_veh\n", FichierVéhicules, true},
+		{"vehicules 2019-2023", enTêteVéhicules, FichierVéhicules, true},
+		{"usagers 2005-2018", "Num_Acc,place,catu,grav,sexe,trajet,secu,locp,actp,etatp,an_nais,num_veh\n", FichierUsagers, true},
+		{"usagers 2019-2023", enTêteUsagers, FichierUsagers, true},
+		{"tabs, quotes, upper case and a byte order mark", "\uFEFF\"NUM_ACC\"\t\"CATU\"\t\"GRAV\"\r\n", FichierUsagers, true},
+		{"some of the columns", "Num_Acc;catv;obs\n", 0, false},
+		{"not a data file", "%PDF-1.4\n", 0, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dataPath := t.TempDir()
+			writeFixtures(t, dataPath, map[string][]byte{"2019/data.csv": []byte(test.enTête)})
+			découverte, err := discoverFiles(dataPath, 2019)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var trouvés []Fichier
+
+			for fichier := range découverte.emplacements {
+				trouvés = append(trouvés, fichier)
+			}
+
+			if !test.reconnu {
+				if len(trouvés) != 0 {
+					t.Errorf("expected the file not to be recognised, got %v", trouvés)
+				}
+
+				return
+			}
+
+			if !slices.Equal(trouvés, []Fichier{test.fichier}) {
+				t.Errorf("expected the file to be recognised as %v, got %v", test.fichier, trouvés)
+			}
+		})
+	}
+}
+
+func TestDiscoverFiles(t *testing.T) {
+	dataPath := t.TempDir()
+
+	writeFixtures(t, dataPath, map[string][]byte{
+		"2019/a.csv":         []byte(enTêteCaractéristiques),
+		"2019/b.csv.gz":      gzipped(t, enTêteLieux),
+		"2019/c.csv":         []byte(enTêteUsagers),
+		"2019/d.csv":         []byte(enTêteUsagers),
+		"2019/e.gz":          []byte("not compressed"),
+		"2019/.hidden.csv":   []byte(enTêteVéhicules),
+		"2019/sub/f.csv":     []byte(enTêteVéhicules),
+		"2019/readme.txt":    []byte("Données 2019\n"),
+		"2018/vehicules.csv": []byte(enTêteVéhicules),
+	})
+
+	découverte, err := discoverFiles(dataPath, 2019)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[Fichier]string{
+		FichierCaractéristiques: "a.csv",
+		FichierLieux:            "b.csv.gz",
+	}
+
+	if len(découverte.emplacements) != len(expected) {
+		t.Errorf("expected %v files, got %v", len(expected), découverte.emplacements)
+	}
+
+	for fichier, nom := range expected {
+		if chemin := découverte.emplacements[fichier].Chemin; chemin != filepath.Join(dataPath, "2019", nom) {
+			t.Errorf("expected %v to be %v, got %v", fichier, nom, chemin)
+		}
+	}
+
+	if len(découverte.ambiguïtés) != 1 {
+		t.Errorf("expected the two usagers files to be reported, got %v", découverte.ambiguïtés)
+	}
+
+	if découverte, err := discoverFiles(dataPath, 2020); err != nil || len(découverte.emplacements) != 0 {
+		t.Errorf("expected nothing to be found for a year without a directory, got %v, %v", découverte.emplacements, err)
+	}
+}
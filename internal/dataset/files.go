@@ -0,0 +1,400 @@
+package dataset
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// One of the four files published for each year.
+type Fichier int
+
+const (
+	FichierCaractéristiques Fichier = iota
+	FichierLieux
+	FichierVéhicules
+	FichierUsagers
+)
+
+var Fichiers = []Fichier{FichierCaractéristiques, FichierLieux, FichierVéhicules, FichierUsagers}
+
+func (fichier Fichier) String() string {
+	return [...]string{
+		"caracteristiques",
+		"lieux",
+		"vehicules",
+		"usagers",
+	}[fichier]
+}
+
+func filenameSuffix1(year uint) string {
+	if year <= 2016 {
+		return fmt.Sprintf("_%v.csv", year)
+	} else {
+		return fmt.Sprintf("-%v.csv", year)
+	}
+}
+
+// Returns the name that a file has in the official data for a year.
+func FileName(fichier Fichier, year uint) string {
+	if year <= 2018 {
+		return fmt.Sprintf("%v%v", fichier, filenameSuffix1(year))
+	}
+
+	baseName := fichier.String()
+
+	if fichier == FichierCaractéristiques {
+		if year >= 2023 {
+			baseName = "caract"
+		} else if year == 2021 || year == 2022 {
+			baseName = "carcteristiques"
+		}
+	}
+
+	return fmt.Sprintf("%v-%v.csv", baseName, year)
+}
+
+// Returns the path where the readers look for a file.
+func FilePath(dataPath string, fichier Fichier, year uint) string {
+	return filepath.Join(dataPath, fmt.Sprint(year), FileName(fichier, year))
+}
+
+var nomDeFichierRegexp = regexp.MustCompile(
+	`^(caract|carac|caracteristiques|caractéristiques|carcteristiques|lieux|vehicules|véhicules|usagers)[-_ ]?(\d{4})\.csv(\.gz)?$`,
+)
+
+// Recognises the name of one of the official files, in any of the forms used over
+// the years, in upper or lower case, and possibly compressed with gzip. Returns the
+// file and the year.
+func IdentifyFile(nom string) (fichier Fichier, year uint, ok bool) {
+	match := nomDeFichierRegexp.FindStringSubmatch(strings.ToLower(path.Base(nom)))
+
+	if match == nil {
+		return 0, 0, false
+	}
+
+	switch match[1] {
+	case "lieux":
+		fichier = FichierLieux
+	case "vehicules", "véhicules":
+		fichier = FichierVéhicules
+	case "usagers":
+		fichier = FichierUsagers
+	default:
+		fichier = FichierCaractéristiques
+	}
+
+	parsedYear, _ := strconv.ParseUint(match[2], 10, 0)
+	return fichier, uint(parsedYear), true
+}
+
+// Where a data file was found: either a file in the data directory, or an entry
+// in a zip archive. Files whose names end in .gz are decompressed when read.
+type Emplacement struct {
+	Chemin string
+	Entrée string
+}
+
+func (emplacement Emplacement) String() string {
+	if emplacement.Entrée == "" {
+		return emplacement.Chemin
+	}
+
+	return fmt.Sprintf("%v in %v", emplacement.Entrée, emplacement.Chemin)
+}
+
+// Opens the file for reading, decompressing it if its name ends in .gz.
+func (emplacement Emplacement) Open() (io.ReadCloser, error) {
+	brut, err := emplacement.openRaw()
+
+	if err != nil {
+		return nil, err
+	}
+
+	nom := emplacement.Chemin
+
+	if emplacement.Entrée != "" {
+		nom = emplacement.Entrée
+	}
+
+	if !strings.HasSuffix(strings.ToLower(nom), ".gz") {
+		return brut, nil
+	}
+
+	gzipReader, err := gzip.NewReader(brut)
+
+	if err != nil {
+		brut.Close()
+		return nil, fmt.Errorf("can't decompress %v: %w", emplacement, err)
+	}
+
+	return &lecteurEmplacement{gzipReader, []io.Closer{brut, gzipReader}}, nil
+}
+
+// Opens the file as it is stored, without decompressing it if its name ends in
+// .gz. An entry in a zip archive is always decompressed.
+func (emplacement Emplacement) openRaw() (io.ReadCloser, error) {
+	if emplacement.Entrée == "" {
+		file, err := os.Open(emplacement.Chemin)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return file, nil
+	}
+
+	archive, file, err := emplacement.findArchiveEntry()
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := file.Open()
+
+	if err != nil {
+		archive.Close()
+		return nil, fmt.Errorf("can't open %v: %w", emplacement, err)
+	}
+
+	return &lecteurEmplacement{entry, []io.Closer{archive, entry}}, nil
+}
+
+// Opens the archive containing the file, and finds the file in it. The caller
+// must close the archive.
+func (emplacement Emplacement) findArchiveEntry() (*zip.ReadCloser, *zip.File, error) {
+	archive, err := zip.OpenReader(emplacement.Chemin)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	index := slices.IndexFunc(archive.File, func(file *zip.File) bool {
+		return file.Name == emplacement.Entrée
+	})
+
+	if index < 0 {
+		archive.Close()
+		return nil, nil, fmt.Errorf("can't find %v: %w", emplacement, os.ErrNotExist)
+	}
+
+	return archive, archive.File[index], nil
+}
+
+// Returns the size and modification date of the file as it is stored, or of the
+// uncompressed entry in a zip archive.
+func (emplacement Emplacement) Info() (taille int64, dateDeModification time.Time, err error) {
+	if emplacement.Entrée == "" {
+		info, err := os.Stat(emplacement.Chemin)
+
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+
+		return info.Size(), info.ModTime(), nil
+	}
+
+	archive, file, err := emplacement.findArchiveEntry()
+
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	defer archive.Close()
+	return int64(file.UncompressedSize64), file.Modified, nil
+}
+
+type lecteurEmplacement struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (lecteur *lecteurEmplacement) Close() error {
+	return closeAll(lecteur.closers)
+}
+
+// Closes in reverse order, and returns the first error.
+func closeAll(closers []io.Closer) error {
+	var firstErr error
+
+	for index := len(closers) - 1; index >= 0; index-- {
+		if err := closers[index].Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// The data files for a year, and where they were found.
+type FichiersAnnuels struct {
+	Year         uint
+	Emplacements map[Fichier]Emplacement
+
+	// Why each file that wasn't found is missing.
+	Introuvables map[Fichier]error
+
+	// Descriptions of the cases where more than one file in the directory for the
+	// year looked like the same one. Those files were found by name instead.
+	Ambiguïtés []string
+}
+
+// Returns where a file was found, or an error for which
+// errors.Is(err, os.ErrNotExist) is true if it wasn't.
+func (fichiers FichiersAnnuels) Location(fichier Fichier) (Emplacement, error) {
+	if emplacement, ok := fichiers.Emplacements[fichier]; ok {
+		return emplacement, nil
+	}
+
+	return Emplacement{}, fichiers.Introuvables[fichier]
+}
+
+// Finds the data files for a year. For each file, looks in order for:
+//
+//   - a file in <dataPath>/<year>/ whose header shows that it is the one
+//     required, whatever its name (see discoverFiles)
+//   - the file with its official name in <dataPath>/<year>/
+//   - a file in <dataPath>/<year>/ whose name is a variant of the official one,
+//     possibly compressed with gzip
+//   - the file in <dataPath>/<year>.zip
+//   - the file in any other zip archive in <dataPath>, e.g. an archive of all years
+func LocateFiles(dataPath string, year uint) (FichiersAnnuels, error) {
+	fichiers := FichiersAnnuels{
+		Year:         year,
+		Emplacements: make(map[Fichier]Emplacement),
+		Introuvables: make(map[Fichier]error),
+	}
+
+	découverte, err := discoverFiles(dataPath, year)
+
+	if err != nil {
+		return fichiers, err
+	}
+
+	fichiers.Ambiguïtés = découverte.ambiguïtés
+
+	for _, fichier := range Fichiers {
+		if emplacement, ok := découverte.emplacements[fichier]; ok {
+			fichiers.Emplacements[fichier] = emplacement
+			continue
+		}
+
+		emplacement, err := locateByName(dataPath, fichier, year)
+
+		if errors.Is(err, os.ErrNotExist) {
+			fichiers.Introuvables[fichier] = err
+		} else if err != nil {
+			return fichiers, err
+		} else {
+			fichiers.Emplacements[fichier] = emplacement
+		}
+	}
+
+	return fichiers, nil
+}
+
+// Finds a data file by its name, in the data directory or in a zip archive.
+func locateByName(dataPath string, fichier Fichier, year uint) (Emplacement, error) {
+	cheminOfficiel := FilePath(dataPath, fichier, year)
+
+	if _, err := os.Stat(cheminOfficiel); err == nil {
+		return Emplacement{Chemin: cheminOfficiel}, nil
+	}
+
+	matches := func(nom string) bool {
+		foundFichier, foundYear, ok := IdentifyFile(nom)
+		return ok && foundFichier == fichier && foundYear == year
+	}
+
+	yearPath := filepath.Join(dataPath, fmt.Sprint(year))
+
+	if entries, err := os.ReadDir(yearPath); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && matches(entry.Name()) {
+				return Emplacement{Chemin: filepath.Join(yearPath, entry.Name())}, nil
+			}
+		}
+	}
+
+	archives, err := listArchives(dataPath, year)
+
+	if err != nil {
+		return Emplacement{}, err
+	}
+
+	for _, archive := range archives {
+		entrée, err := searchArchive(archive, matches)
+
+		if err != nil {
+			return Emplacement{}, err
+		}
+
+		if entrée != "" {
+			return Emplacement{Chemin: archive, Entrée: entrée}, nil
+		}
+	}
+
+	return Emplacement{}, fmt.Errorf("can't find %v for %v (no variant of %v in %v or in a zip archive in %v): %w",
+		fichier, year, FileName(fichier, year), yearPath, dataPath, os.ErrNotExist)
+}
+
+// Returns the paths of the zip archives in the data directory, starting with the
+// one for a year, if there is one.
+func listArchives(dataPath string, year uint) ([]string, error) {
+	entries, err := os.ReadDir(dataPath)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var archives []string
+	yearArchive := fmt.Sprintf("%v.zip", year)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(strings.ToLower(entry.Name()), ".zip") {
+			continue
+		}
+
+		archive := filepath.Join(dataPath, entry.Name())
+
+		if strings.ToLower(entry.Name()) == yearArchive {
+			archives = append([]string{archive}, archives...)
+		} else {
+			archives = append(archives, archive)
+		}
+	}
+
+	return archives, nil
+}
+
+// Returns the name of the first entry in a zip archive that matches, or an empty
+// string if there is none.
+func searchArchive(archive string, matches func(nom string) bool) (string, error) {
+	reader, err := zip.OpenReader(archive)
+
+	if err != nil {
+		return "", fmt.Errorf("can't read %v: %w", archive, err)
+	}
+
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if !file.FileInfo().IsDir() && matches(file.Name) {
+			return file.Name, nil
+		}
+	}
+
+	return "", nil
+}
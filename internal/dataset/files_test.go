@@ -0,0 +1,250 @@
+package dataset
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Headers that discovery recognises.
+const (
+	enTêteCaractéristiques = "Num_Acc;jour;mois;an;hrmn;lum;dep;com;agg;int;atm;col;adr;lat;long\n"
+	enTêteLieux            = "Num_Acc;catr;voie;v1;v2;circ;nbv;vosp;prof;pr;pr1;plan;lartpc;larrout;surf;infra;situ;vma\n"
+	enTêteVéhicules        = "Num_Acc;id_vehicule;num_veh;senc;catv;obs;obsm;choc;manv;motor;occutc\n"
+	enTêteUsagers          = "Num_Acc;id_vehicule;num_veh;place;catu;grav;sexe;an_nais;trajet;secu1;secu2;secu3;locp;actp;etatp\n"
+)
+
+// Writes files to a directory, creating subdirectories as needed.
+func writeFixtures(t *testing.T, dataPath string, fichiers map[string][]byte) {
+	t.Helper()
+
+	for nom, contenu := range fichiers {
+		chemin := filepath.Join(dataPath, filepath.FromSlash(nom))
+
+		if err := os.MkdirAll(filepath.Dir(chemin), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(chemin, contenu, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func gzipped(t *testing.T, contenu string) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+
+	if _, err := writer.Write([]byte(contenu)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func zipped(t *testing.T, entrées map[string][]byte) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+
+	for nom, contenu := range entrées {
+		entry, err := writer.Create(nom)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := entry.Write(contenu); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func TestIdentifyFile(t *testing.T) {
+	for _, test := range []struct {
+		nom     string
+		fichier Fichier
+		year    uint
+		ok      bool
+	}{
+		{"caracteristiques_2016.csv", FichierCaractéristiques, 2016, true},
+		{"carcteristiques-2021.csv", FichierCaractéristiques, 2021, true},
+		{"caract-2023.csv", FichierCaractéristiques, 2023, true},
+		{"Caractéristiques 2010.csv", FichierCaractéristiques, 2010, true},
+		{"LIEUX-2019.CSV", FichierLieux, 2019, true},
+		{"2019/vehicules-2019.csv.gz", FichierVéhicules, 2019, true},
+		{"accidents.zip!/usagers_2012.csv", FichierUsagers, 2012, true},
+		{"usagers-2019.txt", 0, 0, false},
+		{"description.pdf", 0, 0, false},
+	} {
+		fichier, year, ok := IdentifyFile(test.nom)
+
+		if ok != test.ok || fichier != test.fichier || year != test.year {
+			t.Errorf("%v: expected %v %v %v, got %v %v %v", test.nom, test.fichier, test.year, test.ok, fichier, year, ok)
+		}
+	}
+}
+
+func TestLocateFiles(t *testing.T) {
+	nonReconnu := []byte("pas un fichier de données\n")
+
+	for _, test := range []struct {
+		name       string
+		fichiers   map[string][]byte
+		expected   map[Fichier]string // Manifest keys of the files found
+		ambiguïtés int
+	}{
+		{
+			name: "official names",
+			fichiers: map[string][]byte{
+				"2019/caracteristiques-2019.csv": []byte(enTêteCaractéristiques),
+				"2019/lieux-2019.csv":            []byte(enTêteLieux),
+			},
+			expected: map[Fichier]string{
+				FichierCaractéristiques: "2019/caracteristiques-2019.csv",
+				FichierLieux:            "2019/lieux-2019.csv",
+			},
+		},
+		{
+			name: "a renamed file is found by its header, before a file with the official name",
+			fichiers: map[string][]byte{
+				"2019/export (1).csv": []byte(enTêteLieux),
+				"2019/lieux-2019.csv": nonReconnu,
+			},
+			expected: map[Fichier]string{FichierLieux: "2019/export (1).csv"},
+		},
+		{
+			name: "files with the same header are chosen by name",
+			fichiers: map[string][]byte{
+				"2019/usagers-2019 (1).csv": []byte(enTêteUsagers),
+				"2019/usagers-2019.csv":     []byte(enTêteUsagers),
+				"2019/vehicules.csv":        []byte(enTêteVéhicules),
+			},
+			expected: map[Fichier]string{
+				FichierUsagers:   "2019/usagers-2019.csv",
+				FichierVéhicules: "2019/vehicules.csv",
+			},
+			ambiguïtés: 1,
+		},
+		{
+			name: "a variant of the official name, compressed",
+			fichiers: map[string][]byte{
+				"2019/LIEUX_2019.CSV.GZ": gzipped(t, string(nonReconnu)),
+			},
+			expected: map[Fichier]string{FichierLieux: "2019/LIEUX_2019.CSV.GZ"},
+		},
+		{
+			name: "the archive for the year before other archives",
+			fichiers: map[string][]byte{
+				"2019.zip":      zipped(t, map[string][]byte{"lieux-2019.csv": []byte(enTêteLieux)}),
+				"accidents.zip": zipped(t, map[string][]byte{"2019/lieux-2019.csv": []byte(enTêteLieux)}),
+			},
+			expected: map[Fichier]string{FichierLieux: "2019.zip!/lieux-2019.csv"},
+		},
+		{
+			name: "an archive of all years",
+			fichiers: map[string][]byte{
+				"accidents.zip": zipped(t, map[string][]byte{
+					"2018/lieux-2018.csv":    []byte(enTêteLieux),
+					"2019/usagers-2019.csv":  []byte(enTêteUsagers),
+					"2019/Lieux-2019.csv.gz": gzipped(t, enTêteLieux),
+				}),
+			},
+			expected: map[Fichier]string{
+				FichierLieux:   "accidents.zip!/2019/Lieux-2019.csv.gz",
+				FichierUsagers: "accidents.zip!/2019/usagers-2019.csv",
+			},
+		},
+		{
+			name:     "no files",
+			fichiers: map[string][]byte{"2018/lieux-2018.csv": []byte(enTêteLieux)},
+			expected: map[Fichier]string{},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dataPath := t.TempDir()
+			writeFixtures(t, dataPath, test.fichiers)
+			fichiers, err := LocateFiles(dataPath, 2019)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(fichiers.Ambiguïtés) != test.ambiguïtés {
+				t.Errorf("expected %v ambiguities, got %v", test.ambiguïtés, fichiers.Ambiguïtés)
+			}
+
+			for _, fichier := range Fichiers {
+				emplacement, err := fichiers.Location(fichier)
+
+				if expected, ok := test.expected[fichier]; ok {
+					if err != nil {
+						t.Errorf("expected %v to be found, got %v", fichier, err)
+					} else if clé := emplacement.ManifestKey(dataPath); clé != expected {
+						t.Errorf("expected %v to be %v, got %v", fichier, expected, clé)
+					}
+				} else if !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("expected %v not to be found, got %v, %v", fichier, emplacement, err)
+				}
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	contenu := enTêteLieux + "201900000001;4;RUE;0;N/A;2;2;1;1;(1);(1);1;;-1;2;0;1;50\n"
+	dataPath := t.TempDir()
+
+	writeFixtures(t, dataPath, map[string][]byte{
+		"lieux.csv":    []byte(contenu),
+		"lieux.csv.gz": gzipped(t, contenu),
+		"lieux.zip": zipped(t, map[string][]byte{
+			"lieux.csv":    []byte(contenu),
+			"lieux.csv.gz": gzipped(t, contenu),
+		}),
+	})
+
+	for _, emplacement := range []Emplacement{
+		{Chemin: filepath.Join(dataPath, "lieux.csv")},
+		{Chemin: filepath.Join(dataPath, "lieux.csv.gz")},
+		{Chemin: filepath.Join(dataPath, "lieux.zip"), Entrée: "lieux.csv"},
+		{Chemin: filepath.Join(dataPath, "lieux.zip"), Entrée: "lieux.csv.gz"},
+	} {
+		file, err := emplacement.Open()
+
+		if err != nil {
+			t.Fatalf("%v: %v", emplacement, err)
+		}
+
+		lu, err := io.ReadAll(file)
+		file.Close()
+
+		if err != nil {
+			t.Fatalf("%v: %v", emplacement, err)
+		}
+
+		if string(lu) != contenu {
+			t.Errorf("%v: expected %q, got %q", emplacement, contenu, lu)
+		}
+	}
+
+	if _, err := (Emplacement{Chemin: filepath.Join(dataPath, "lieux.zip"), Entrée: "usagers.csv"}).Open(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing entry not to exist, got %v", err)
+	}
+}
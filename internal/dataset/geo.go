@@ -0,0 +1,62 @@
+package dataset
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A row of output that has a geographical position, given as French-formatted
+// latitude and longitude strings.
+type Localisé interface {
+	Position() (latitude string, longitude string)
+}
+
+// Parses a French-formatted latitude and longitude (with a comma as the decimal
+// separator). Returns false if either is missing or invalid, or if both are zero,
+// which some years use to mean that the position is unknown.
+func ParsePosition(latitudeStr string, longitudeStr string) (latitude float64, longitude float64, ok bool) {
+	latitude, err := parseCoordonnée(latitudeStr)
+
+	if err != nil {
+		return 0, 0, false
+	}
+
+	longitude, err = parseCoordonnée(longitudeStr)
+
+	if err != nil {
+		return 0, 0, false
+	}
+
+	if (latitude == 0 && longitude == 0) ||
+		latitude < -90 || latitude > 90 ||
+		longitude < -180 || longitude > 180 {
+		return 0, 0, false
+	}
+
+	return latitude, longitude, true
+}
+
+func parseCoordonnée(str string) (float64, error) {
+	return strconv.ParseFloat(strings.Replace(strings.TrimSpace(str), ",", ".", 1), 64)
+}
+
+// Returns the positions of rows that have one, and the number of rows that were
+// skipped because they had no valid position.
+func positionsOf(objs []any) (positions map[int][2]float64, skipped int) {
+	positions = make(map[int][2]float64)
+
+	for index, obj := range objs {
+		if localisé, ok := obj.(Localisé); ok {
+			latitudeStr, longitudeStr := localisé.Position()
+
+			if latitude, longitude, ok := ParsePosition(latitudeStr, longitudeStr); ok {
+				positions[index] = [2]float64{latitude, longitude}
+				continue
+			}
+		}
+
+		skipped++
+	}
+
+	return positions, skipped
+}
@@ -0,0 +1,127 @@
+package dataset
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// The character encoding of a data file.
+type Encodage int
+
+const (
+	EncodageDétecté Encodage = iota
+	EncodageUtf8
+	EncodageWindows1252
+)
+
+func (encodage Encodage) String() string {
+	return [...]string{
+		"auto",
+		"utf-8",
+		"windows-1252",
+	}[encodage]
+}
+
+// Overrides the detection of the format of the data files for a year. Zero
+// values mean that the delimiter or encoding is detected.
+type FormatDEntrée struct {
+	Délimiteur rune
+	Encodage   Encodage
+}
+
+// The formats of the data files for years in which detection doesn't work.
+var FormatsDEntrée = make(map[uint]FormatDEntrée)
+
+// Returns a reader that converts a data file to UTF-8. When the encoding is
+// detected, each line that isn't valid UTF-8 is taken to be Windows-1252, which
+// older files use, and which is a superset of Latin-1 for printable characters.
+func decode(reader io.Reader, encodage Encodage) io.Reader {
+	switch encodage {
+	case EncodageUtf8:
+		return reader
+	case EncodageWindows1252:
+		return charmap.Windows1252.NewDecoder().Reader(reader)
+	default:
+		return &lecteurDétectantEncodage{reader: bufio.NewReader(reader)}
+	}
+}
+
+type lecteurDétectantEncodage struct {
+	reader  *bufio.Reader
+	pending []byte
+	err     error
+}
+
+func (lecteur *lecteurDétectantEncodage) Read(buffer []byte) (int, error) {
+	for len(lecteur.pending) == 0 {
+		if lecteur.err != nil {
+			return 0, lecteur.err
+		}
+
+		line, err := lecteur.reader.ReadBytes('\n')
+		lecteur.err = err
+
+		if !utf8.Valid(line) {
+			line, err = charmap.Windows1252.NewDecoder().Bytes(line)
+
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		lecteur.pending = line
+	}
+
+	count := copy(buffer, lecteur.pending)
+	lecteur.pending = lecteur.pending[count:]
+	return count, nil
+}
+
+var délimiteursPossibles = []rune{',', ';', '\t'}
+
+// Returns the delimiter that occurs most often in the first line of a file,
+// outside quoted column names.
+func detectDelimiter(reader *bufio.Reader, path string) (rune, error) {
+	// The header is much shorter than the buffer.
+	peeked, err := reader.Peek(reader.Size())
+
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return 0, err
+	}
+
+	if index := bytes.IndexByte(peeked, '\n'); index >= 0 {
+		peeked = peeked[:index]
+	}
+
+	counts := make(map[rune]int)
+	quoted := false
+
+	for _, r := range string(peeked) {
+		if r == '"' {
+			quoted = !quoted
+		} else if !quoted {
+			counts[r]++
+		}
+	}
+
+	var délimiteur rune
+	maxCount := 0
+
+	for _, possible := range délimiteursPossibles {
+		if count := counts[possible]; count > maxCount {
+			délimiteur = possible
+			maxCount = count
+		}
+	}
+
+	if maxCount == 0 {
+		return 0, fmt.Errorf("can't detect the delimiter in %v", path)
+	}
+
+	return délimiteur, nil
+}
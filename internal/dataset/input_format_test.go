@@ -0,0 +1,74 @@
+package dataset
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		encodage Encodage
+		input    string
+		expected string
+	}{
+		{"UTF-8", EncodageDétecté, "adr\nRUE DE L'ÉGLISE\n", "adr\nRUE DE L'ÉGLISE\n"},
+		{"Windows-1252", EncodageDétecté, "adr\nRUE DE L\x92\xc9GLISE\n", "adr\nRUE DE L’ÉGLISE\n"},
+		{"each line separately", EncodageDétecté, "Évry\nCr\xe9teil\nSèvres\nNo\xebl", "Évry\nCréteil\nSèvres\nNoël"},
+		{"a line longer than the buffer", EncodageDétecté, strings.Repeat("é", 5000) + "\n\xe9", strings.Repeat("é", 5000) + "\né"},
+		{"specified as UTF-8", EncodageUtf8, "Cr\xe9teil", "Cr\xe9teil"},
+		{"specified as Windows-1252", EncodageWindows1252, "CrÃ©teil", "CrÃƒÂ©teil"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			decoded, err := io.ReadAll(decode(strings.NewReader(test.input), test.encodage))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(decoded) != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, decoded)
+			}
+		})
+	}
+}
+
+func TestDetectDelimiter(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		input    string
+		expected rune
+	}{
+		{"commas", "Num_Acc,an,mois,jour\n2018,18,1,24\n", ','},
+		{"semicolons", "\"Num_Acc\";\"jour\";\"mois\"\n\"2019\";\"1\";\"2\"\n", ';'},
+		{"tabs", "Num_Acc\tan\tmois\n", '\t'},
+		{"quoted commas", "\"Num_Acc\";\"adr, voie, lieu-dit\";\"lat,long\"\n", ';'},
+		{"quoted semicolons", "\"a;b;c\",\"d\",\"e\"\n", ','},
+		{"only the first line", "Num_Acc;an\n1,2,3,4,5,6\n", ';'},
+		{"no line ending", "Num_Acc\tan", '\t'},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			reader := bufio.NewReader(strings.NewReader(test.input))
+			délimiteur, err := detectDelimiter(reader, "test.csv")
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if délimiteur != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, délimiteur)
+			}
+
+			// The reader must not have been advanced.
+			if rest, _ := io.ReadAll(reader); string(rest) != test.input {
+				t.Errorf("expected the input to be unread, got %q", rest)
+			}
+		})
+	}
+
+	if _, err := detectDelimiter(bufio.NewReader(strings.NewReader("Num_Acc\n")), "test.csv"); err == nil {
+		t.Error("expected an error for a header with one column")
+	}
+}
@@ -0,0 +1,210 @@
+package dataset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The name of the manifest file in the data directory.
+const NomDuManifeste = "manifest.json"
+
+// Describes the data files that are known to be good, so that stale or partially
+// downloaded files can be detected. Files are identified by their path relative to
+// the data directory, with forward slashes (e.g. "2019/lieux-2019.csv").
+type Manifeste struct {
+	Fichiers map[string]EntréeManifeste `json:"files"`
+}
+
+type EntréeManifeste struct {
+	Taille               int64  `json:"size"`
+	Sha256               string `json:"sha256"`
+	DateDeModification   string `json:"modified,omitempty"`
+	Source               string `json:"source,omitempty"`
+	DateDeTéléchargement string `json:"downloaded,omitempty"`
+}
+
+func NewManifeste() *Manifeste {
+	return &Manifeste{Fichiers: make(map[string]EntréeManifeste)}
+}
+
+// Reads the manifest in a data directory. If there isn't one, returns an error
+// for which errors.Is(err, os.ErrNotExist) is true.
+func ReadManifest(dataPath string) (*Manifeste, error) {
+	content, err := os.ReadFile(filepath.Join(dataPath, NomDuManifeste))
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifeste := NewManifeste()
+
+	if err := json.Unmarshal(content, manifeste); err != nil {
+		return nil, fmt.Errorf("can't parse %v: %w", filepath.Join(dataPath, NomDuManifeste), err)
+	}
+
+	if manifeste.Fichiers == nil {
+		manifeste.Fichiers = make(map[string]EntréeManifeste)
+	}
+
+	return manifeste, nil
+}
+
+// Reads the manifest in a data directory, or returns an empty one if there isn't one.
+func ReadOrCreateManifest(dataPath string) (*Manifeste, error) {
+	manifeste, err := ReadManifest(dataPath)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return NewManifeste(), nil
+	}
+
+	return manifeste, err
+}
+
+// Writes the manifest to a data directory, with the files in alphabetical order.
+func (manifeste *Manifeste) Write(dataPath string) error {
+	content, err := json.MarshalIndent(manifeste, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dataPath, NomDuManifeste), append(content, '\n'), 0644)
+}
+
+var annéeDeRépertoireRegexp = regexp.MustCompile(`^(\d{4})(\.zip)?$`)
+
+// Returns the year of the file that a key in the manifest refers to: the year in
+// the name of the file, or else the year of the directory or archive containing
+// it (e.g. "2019/lieux.csv" or "2019.zip!/usagers.csv").
+func KeyYear(clé string) (year uint, ok bool) {
+	if _, year, ok := IdentifyFile(clé); ok {
+		return year, true
+	}
+
+	for _, composant := range strings.FieldsFunc(clé, func(r rune) bool { return r == '/' || r == '!' }) {
+		if match := annéeDeRépertoireRegexp.FindStringSubmatch(strings.ToLower(composant)); match != nil {
+			parsedYear, _ := strconv.ParseUint(match[1], 10, 0)
+			return uint(parsedYear), true
+		}
+	}
+
+	return 0, false
+}
+
+// Returns the keys of the manifest in alphabetical order.
+func (manifeste *Manifeste) Keys() []string {
+	var clés []string
+
+	for clé := range manifeste.Fichiers {
+		clés = append(clés, clé)
+	}
+
+	sort.Strings(clés)
+	return clés
+}
+
+// Returns the key of a file in the manifest: its path relative to the data
+// directory, followed, for an entry in a zip archive, by "!/" and the name of
+// the entry (e.g. "2019.zip!/lieux-2019.csv").
+func (emplacement Emplacement) ManifestKey(dataPath string) string {
+	clé, err := filepath.Rel(dataPath, emplacement.Chemin)
+
+	if err != nil {
+		clé = emplacement.Chemin
+	}
+
+	clé = filepath.ToSlash(clé)
+
+	if emplacement.Entrée != "" {
+		clé += "!/" + emplacement.Entrée
+	}
+
+	return clé
+}
+
+// Returns the size, SHA-256 checksum and modification date of a file as it is
+// stored, or of the uncompressed entry in a zip archive.
+func (emplacement Emplacement) Describe() (EntréeManifeste, error) {
+	_, modifié, err := emplacement.Info()
+
+	if err != nil {
+		return EntréeManifeste{}, err
+	}
+
+	file, err := emplacement.openRaw()
+
+	if err != nil {
+		return EntréeManifeste{}, err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+	taille, err := io.Copy(hash, file)
+
+	if err != nil {
+		return EntréeManifeste{}, err
+	}
+
+	return EntréeManifeste{
+		Taille:             taille,
+		Sha256:             hex.EncodeToString(hash.Sum(nil)),
+		DateDeModification: formatModificationDate(modifié),
+	}, nil
+}
+
+func formatModificationDate(modifié time.Time) string {
+	return modifié.UTC().Format(time.RFC3339Nano)
+}
+
+// Checks a file against its entry in the manifest, and returns a description of
+// the problem if it doesn't match, or an empty string if it does. Unless complet
+// is true, a file whose size and modification date match isn't read, so that
+// checking is fast enough to do whenever the files are read.
+func (manifeste *Manifeste) Verify(dataPath string, emplacement Emplacement, complet bool) (string, error) {
+	clé := emplacement.ManifestKey(dataPath)
+	entrée, ok := manifeste.Fichiers[clé]
+
+	if !ok {
+		return fmt.Sprintf("%v is not in the manifest", clé), nil
+	}
+
+	taille, modifié, err := emplacement.Info()
+
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Sprintf("%v is missing", clé), nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if taille != entrée.Taille {
+		return fmt.Sprintf("%v has size %v, expected %v", clé, taille, entrée.Taille), nil
+	}
+
+	if !complet && formatModificationDate(modifié) == entrée.DateDeModification {
+		return "", nil
+	}
+
+	actuel, err := emplacement.Describe()
+
+	if err != nil {
+		return "", err
+	}
+
+	if actuel.Sha256 != entrée.Sha256 {
+		return fmt.Sprintf("%v has SHA-256 checksum %v, expected %v", clé, actuel.Sha256, entrée.Sha256), nil
+	}
+
+	return "", nil
+}
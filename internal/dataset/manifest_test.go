@@ -0,0 +1,197 @@
+package dataset
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManifestKey(t *testing.T) {
+	dataPath := filepath.Join("data", "accidents")
+
+	for _, test := range []struct {
+		emplacement Emplacement
+		expected    string
+	}{
+		{Emplacement{Chemin: filepath.Join(dataPath, "2019", "lieux-2019.csv")}, "2019/lieux-2019.csv"},
+		{Emplacement{Chemin: filepath.Join(dataPath, "2019", "export (1).csv.gz")}, "2019/export (1).csv.gz"},
+		{Emplacement{Chemin: filepath.Join(dataPath, "2019.zip"), Entrée: "LIEUX-2019.CSV"}, "2019.zip!/LIEUX-2019.CSV"},
+		{Emplacement{Chemin: filepath.Join(dataPath, "accidents.zip"), Entrée: "2019/lieux-2019.csv"}, "accidents.zip!/2019/lieux-2019.csv"},
+	} {
+		if clé := test.emplacement.ManifestKey(dataPath); clé != test.expected {
+			t.Errorf("expected the key of %v to be %v, got %v", test.emplacement, test.expected, clé)
+		}
+	}
+}
+
+func TestKeyYear(t *testing.T) {
+	for _, test := range []struct {
+		clé  string
+		year uint
+		ok   bool
+	}{
+		{"2019/lieux-2019.csv", 2019, true},
+		{"2019/export (1).csv", 2019, true},
+		{"2019.zip!/usagers.csv", 2019, true},
+		{"accidents.zip!/lieux_2016.csv", 2016, true},
+		{"accidents.zip!/2017/vehicules.csv", 2017, true},
+		{"accidents.zip!/notes.txt", 0, false},
+	} {
+		if year, ok := KeyYear(test.clé); year != test.year || ok != test.ok {
+			t.Errorf("expected the year of %v to be %v %v, got %v %v", test.clé, test.year, test.ok, year, ok)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	const contenu = enTêteLieux + "201900000001;4;RUE;0;N/A;2;2;1;1;(1);(1);1;;-1;2;0;1;50\n"
+	unJour := 24 * time.Hour
+
+	for _, test := range []struct {
+		name     string
+		modifier func(t *testing.T, chemin string, modifié time.Time) // Changes the file after it has been described
+		complet  bool
+		expected string // Part of the problem that is expected, or "" if the file matches
+	}{
+		{
+			name:     "unchanged",
+			modifier: func(t *testing.T, chemin string, modifié time.Time) {},
+		},
+		{
+			name: "touched",
+			modifier: func(t *testing.T, chemin string, modifié time.Time) {
+				touch(t, chemin, modifié.Add(unJour))
+			},
+		},
+		{
+			name: "different size",
+			modifier: func(t *testing.T, chemin string, modifié time.Time) {
+				writeFile(t, chemin, contenu+"\n", modifié)
+			},
+			expected: "has size",
+		},
+		{
+			name: "different contents and date",
+			modifier: func(t *testing.T, chemin string, modifié time.Time) {
+				writeFile(t, chemin, strings.Replace(contenu, "RUE", "AVE", 1), modifié.Add(unJour))
+			},
+			expected: "has SHA-256 checksum",
+		},
+		{
+			// The checksum isn't computed if the size and date are the same.
+			name: "different contents, same size and date",
+			modifier: func(t *testing.T, chemin string, modifié time.Time) {
+				writeFile(t, chemin, strings.Replace(contenu, "RUE", "AVE", 1), modifié)
+			},
+		},
+		{
+			name: "different contents, same size and date, complete check",
+			modifier: func(t *testing.T, chemin string, modifié time.Time) {
+				writeFile(t, chemin, strings.Replace(contenu, "RUE", "AVE", 1), modifié)
+			},
+			complet:  true,
+			expected: "has SHA-256 checksum",
+		},
+		{
+			name: "deleted",
+			modifier: func(t *testing.T, chemin string, modifié time.Time) {
+				if err := os.Remove(chemin); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expected: "is missing",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dataPath := t.TempDir()
+			emplacement := Emplacement{Chemin: FilePath(dataPath, FichierLieux, 2019)}
+			modifié := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+			if err := os.MkdirAll(filepath.Dir(emplacement.Chemin), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			writeFile(t, emplacement.Chemin, contenu, modifié)
+			entrée, err := emplacement.Describe()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			manifeste := NewManifeste()
+			manifeste.Fichiers[emplacement.ManifestKey(dataPath)] = entrée
+
+			if err := manifeste.Write(dataPath); err != nil {
+				t.Fatal(err)
+			}
+
+			if manifeste, err = ReadManifest(dataPath); err != nil {
+				t.Fatal(err)
+			}
+
+			test.modifier(t, emplacement.Chemin, modifié)
+			problem, err := manifeste.Verify(dataPath, emplacement, test.complet)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expected == "" && problem != "" {
+				t.Errorf("expected the file to match, got %v", problem)
+			} else if !strings.Contains(problem, test.expected) {
+				t.Errorf("expected a problem containing %q, got %q", test.expected, problem)
+			}
+		})
+	}
+}
+
+func TestVerifyArchiveEntry(t *testing.T) {
+	dataPath := t.TempDir()
+
+	writeFixtures(t, dataPath, map[string][]byte{
+		"2019.zip": zipped(t, map[string][]byte{"lieux-2019.csv": []byte(enTêteLieux)}),
+	})
+
+	emplacement := Emplacement{Chemin: filepath.Join(dataPath, "2019.zip"), Entrée: "lieux-2019.csv"}
+	entrée, err := emplacement.Describe()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entrée.Taille != int64(len(enTêteLieux)) {
+		t.Errorf("expected the size of the extracted entry, got %v", entrée.Taille)
+	}
+
+	manifeste := NewManifeste()
+
+	if problem, err := manifeste.Verify(dataPath, emplacement, true); err != nil || problem != "2019.zip!/lieux-2019.csv is not in the manifest" {
+		t.Errorf("expected the entry not to be in the manifest, got %q, %v", problem, err)
+	}
+
+	manifeste.Fichiers["2019.zip!/lieux-2019.csv"] = entrée
+
+	if problem, err := manifeste.Verify(dataPath, emplacement, true); err != nil || problem != "" {
+		t.Errorf("expected the entry to match, got %q, %v", problem, err)
+	}
+}
+
+func writeFile(t *testing.T, chemin string, contenu string, modifié time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(chemin, []byte(contenu), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	touch(t, chemin, modifié)
+}
+
+func touch(t *testing.T, chemin string, modifié time.Time) {
+	t.Helper()
+
+	if err := os.Chtimes(chemin, modifié, modifié); err != nil {
+		t.Fatal(err)
+	}
+}
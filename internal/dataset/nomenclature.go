@@ -0,0 +1,390 @@
+package dataset
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// A code from the official data files, with its label in the official nomenclature.
+type CodeOfficiel struct {
+	Code    string
+	Libellé string
+}
+
+// The official codes read from a row of a data file, by column name.
+type CodesOfficiels map[string]CodeOfficiel
+
+// A value that is derived from an official code, and remembers that code.
+type Codé[T any] struct {
+	Valeur T
+	Code   CodeOfficiel
+}
+
+func (codé Codé[T]) String() string {
+	return fmt.Sprint(codé.Valeur)
+}
+
+func (codé Codé[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(codé.Valeur)
+}
+
+func (codé Codé[T]) CodeOfficiel() CodeOfficiel {
+	return codé.Code
+}
+
+// Values that remember the official code they were derived from.
+type AvecCodeOfficiel interface {
+	CodeOfficiel() CodeOfficiel
+}
+
+func NewCodé[T any](valeur T, codesOfficiels CodesOfficiels, columnName string) Codé[T] {
+	return Codé[T]{
+		Valeur: valeur,
+		Code:   codesOfficiels[columnName],
+	}
+}
+
+// Reads the official codes in the specified columns of a row. Numeric codes are
+// normalised, because some files pad them with zeros and others don't.
+func readCodesOfficiels(row map[string]string, columnNames ...string) CodesOfficiels {
+	codesOfficiels := make(CodesOfficiels)
+
+	for _, columnName := range columnNames {
+		code, ok := row[columnName]
+
+		if !ok || code == "" {
+			continue
+		}
+
+		if codeInt, err := strconv.Atoi(code); err == nil {
+			code = fmt.Sprint(codeInt)
+		}
+
+		codesOfficiels[columnName] = CodeOfficiel{
+			Code:    code,
+			Libellé: nomenclature[columnName][code],
+		}
+	}
+
+	return codesOfficiels
+}
+
+// Labels of the official codes, by column name. Codes that are only used in some
+// years are included, because they don't conflict with each other, except for 0 in
+// 'infra' and 'situ', which YearDatasetReader1 labels as "Non renseigné".
+var nomenclature = map[string]map[string]string{
+	"lum": {
+		"-1": "Non renseigné",
+		"1":  "Plein jour",
+		"2":  "Crépuscule ou aube",
+		"3":  "Nuit sans éclairage public",
+		"4":  "Nuit avec éclairage public non allumé",
+		"5":  "Nuit avec éclairage public allumé",
+	},
+	"agg": {
+		"-1": "Non renseigné",
+		"1":  "Hors agglomération",
+		"2":  "En agglomération",
+	},
+	"int": {
+		"-1": "Non renseigné",
+		"0":  "Non renseigné",
+		"1":  "Hors intersection",
+		"2":  "Intersection en X",
+		"3":  "Intersection en T",
+		"4":  "Intersection en Y",
+		"5":  "Intersection à plus de 4 branches",
+		"6":  "Giratoire",
+		"7":  "Place",
+		"8":  "Passage à niveau",
+		"9":  "Autre intersection",
+	},
+	"atm": {
+		"-1": "Non renseigné",
+		"1":  "Normale",
+		"2":  "Pluie légère",
+		"3":  "Pluie forte",
+		"4":  "Neige - grêle",
+		"5":  "Brouillard - fumée",
+		"6":  "Vent fort - tempête",
+		"7":  "Temps éblouissant",
+		"8":  "Temps couvert",
+		"9":  "Autre",
+	},
+	"col": {
+		"-1": "Non renseigné",
+		"1":  "Deux véhicules - frontale",
+		"2":  "Deux véhicules - par l'arrière",
+		"3":  "Deux véhicules - par le côté",
+		"4":  "Trois véhicules et plus - en chaîne",
+		"5":  "Trois véhicules et plus - collisions multiples",
+		"6":  "Autre collision",
+		"7":  "Sans collision",
+	},
+	"catr": {
+		"-1": "Non renseigné",
+		"1":  "Autoroute",
+		"2":  "Route nationale",
+		"3":  "Route départementale",
+		"4":  "Voie communale",
+		"5":  "Hors réseau public",
+		"6":  "Parc de stationnement ouvert à la circulation publique",
+		"7":  "Routes de métropole urbaine",
+		"9":  "Autre",
+	},
+	"circ": {
+		"-1": "Non renseigné",
+		"0":  "Non renseigné",
+		"1":  "A sens unique",
+		"2":  "Bidirectionnelle",
+		"3":  "A chaussées séparées",
+		"4":  "Avec voies d'affectation variable",
+	},
+	"vosp": {
+		"-1": "Non renseigné",
+		"0":  "Sans objet",
+		"1":  "Piste cyclable",
+		"2":  "Bande cyclable",
+		"3":  "Voie réservée",
+	},
+	"prof": {
+		"-1": "Non renseigné",
+		"0":  "Non renseigné",
+		"1":  "Plat",
+		"2":  "Pente",
+		"3":  "Sommet de côte",
+		"4":  "Bas de côte",
+	},
+	"plan": {
+		"-1": "Non renseigné",
+		"0":  "Non renseigné",
+		"1":  "Partie rectiligne",
+		"2":  "En courbe à gauche",
+		"3":  "En courbe à droite",
+		"4":  "En « S »",
+	},
+	"surf": {
+		"-1": "Non renseigné",
+		"0":  "Non renseigné",
+		"1":  "Normale",
+		"2":  "Mouillée",
+		"3":  "Flaques",
+		"4":  "Inondée",
+		"5":  "Enneigée",
+		"6":  "Boue",
+		"7":  "Verglacée",
+		"8":  "Corps gras - huile",
+		"9":  "Autre",
+	},
+	"infra": {
+		"-1": "Non renseigné",
+		"0":  "Aucun",
+		"1":  "Souterrain - tunnel",
+		"2":  "Pont - autopont",
+		"3":  "Bretelle d'échangeur ou de raccordement",
+		"4":  "Voie ferrée",
+		"5":  "Carrefour aménagé",
+		"6":  "Zone piétonne",
+		"7":  "Zone de péage",
+		"8":  "Chantier",
+		"9":  "Autres",
+	},
+	"situ": {
+		"-1": "Non renseigné",
+		"0":  "Aucun",
+		"1":  "Sur chaussée",
+		"2":  "Sur bande d'arrêt d'urgence",
+		"3":  "Sur accotement",
+		"4":  "Sur trottoir",
+		"5":  "Sur piste cyclable",
+		"6":  "Sur autre voie spéciale",
+		"8":  "Autres",
+	},
+	"senc": {
+		"-1": "Non renseigné",
+		"0":  "Inconnu",
+		"1":  "PK ou PR ou numéro d'adresse postale croissant",
+		"2":  "PK ou PR ou numéro d'adresse postale décroissant",
+		"3":  "Absence de repère",
+	},
+	"catv": {
+		"0":  "Indéterminable",
+		"1":  "Bicyclette",
+		"2":  "Cyclomoteur <50cm3",
+		"3":  "Voiturette (Quadricycle à moteur carrossé)",
+		"4":  "Scooter immatriculé",
+		"5":  "Motocyclette",
+		"6":  "Side-car",
+		"7":  "VL seul",
+		"8":  "VL + caravane",
+		"9":  "VL + remorque",
+		"10": "VU seul 1,5T <= PTAC <= 3,5T avec ou sans remorque",
+		"11": "VU (10) + caravane",
+		"12": "VU (10) + remorque",
+		"13": "PL seul 3,5T <PTCA <= 7,5T",
+		"14": "PL seul > 7,5T",
+		"15": "PL > 3,5T + remorque",
+		"16": "Tracteur routier seul",
+		"17": "Tracteur routier + semi-remorque",
+		"18": "Transport en commun",
+		"19": "Tramway",
+		"20": "Engin spécial",
+		"21": "Tracteur agricole",
+		"30": "Scooter < 50 cm3",
+		"31": "Motocyclette > 50 cm3 et <= 125 cm3",
+		"32": "Scooter > 50 cm3 et <= 125 cm3",
+		"33": "Motocyclette > 125 cm3",
+		"34": "Scooter > 125 cm3",
+		"35": "Quad léger <= 50 cm3",
+		"36": "Quad lourd > 50 cm3",
+		"37": "Autobus",
+		"38": "Autocar",
+		"39": "Train",
+		"40": "Tramway",
+		"41": "3RM <= 50 cm3",
+		"42": "3RM > 50 cm3 <= 125 cm3",
+		"43": "3RM > 125 cm3",
+		"50": "EDP à moteur",
+		"60": "EDP sans moteur",
+		"80": "VAE",
+		"99": "Autre véhicule",
+	},
+	"obs": {
+		"-1": "Non renseigné",
+		"0":  "Sans objet",
+		"1":  "Véhicule en stationnement",
+		"2":  "Arbre",
+		"3":  "Glissière métallique",
+		"4":  "Glissière béton",
+		"5":  "Autre glissière",
+		"6":  "Bâtiment, mur, pile de pont",
+		"7":  "Support de signalisation verticale ou poste d'appel d'urgence",
+		"8":  "Poteau",
+		"9":  "Mobilier urbain",
+		"10": "Parapet",
+		"11": "Ilot, refuge, borne haute",
+		"12": "Bordure de trottoir",
+		"13": "Fossé, talus, paroi rocheuse",
+		"14": "Autre obstacle fixe sur chaussée",
+		"15": "Autre obstacle fixe sur trottoir ou accotement",
+		"16": "Sortie de chaussée sans obstacle",
+		"17": "Buse - tête d'aqueduc",
+	},
+	"obsm": {
+		"-1": "Non renseigné",
+		"0":  "Aucun",
+		"1":  "Piéton",
+		"2":  "Véhicule",
+		"4":  "Véhicule sur rail",
+		"5":  "Animal domestique",
+		"6":  "Animal sauvage",
+		"9":  "Autre",
+	},
+	"choc": {
+		"-1": "Non renseigné",
+		"0":  "Aucun",
+		"1":  "Avant",
+		"2":  "Avant droit",
+		"3":  "Avant gauche",
+		"4":  "Arrière",
+		"5":  "Arrière droit",
+		"6":  "Arrière gauche",
+		"7":  "Côté droit",
+		"8":  "Côté gauche",
+		"9":  "Chocs multiples (tonneaux)",
+	},
+	"manv": {
+		"-1": "Non renseigné",
+		"0":  "Inconnue",
+		"1":  "Sans changement de direction",
+		"2":  "Même sens, même file",
+		"3":  "Entre 2 files",
+		"4":  "En marche arrière",
+		"5":  "A contresens",
+		"6":  "En franchissant le terre-plein central",
+		"7":  "Dans le couloir bus, dans le même sens",
+		"8":  "Dans le couloir bus, dans le sens inverse",
+		"9":  "En s'insérant",
+		"10": "En faisant demi-tour sur la chaussée",
+		"11": "Changeant de file - A gauche",
+		"12": "Changeant de file - A droite",
+		"13": "Déporté - A gauche",
+		"14": "Déporté - A droite",
+		"15": "Tournant - A gauche",
+		"16": "Tournant - A droite",
+		"17": "Dépassant - A gauche",
+		"18": "Dépassant - A droite",
+		"19": "Traversant la chaussée",
+		"20": "Manœuvre de stationnement",
+		"21": "Manœuvre d'évitement",
+		"22": "Ouverture de porte",
+		"23": "Arrêté (hors stationnement)",
+		"24": "En stationnement (avec occupants)",
+		"25": "Circulant sur trottoir",
+		"26": "Autres manœuvres",
+	},
+	"catu": {
+		"1": "Conducteur",
+		"2": "Passager",
+		"3": "Piéton",
+		"4": "Piéton en roller ou en trottinette",
+	},
+	"trajet": {
+		"-1": "Non renseigné",
+		"0":  "Non renseigné",
+		"1":  "Domicile - travail",
+		"2":  "Domicile - école",
+		"3":  "Courses - achats",
+		"4":  "Utilisation professionnelle",
+		"5":  "Promenade - loisirs",
+		"9":  "Autre",
+	},
+	"place": {
+		"10": "Piéton (non applicable)",
+	},
+	"grav": {
+		"-1": "Non renseigné",
+		"1":  "Indemne",
+		"2":  "Tué",
+		"3":  "Blessé hospitalisé",
+		"4":  "Blessé léger",
+	},
+	"sexe": {
+		"-1": "Non renseigné",
+		"1":  "Masculin",
+		"2":  "Féminin",
+	},
+	"locp": {
+		"-1": "Non renseigné",
+		"0":  "Sans objet",
+		"1":  "Sur chaussée - A + 50 m du passage piéton",
+		"2":  "Sur chaussée - A – 50 m du passage piéton",
+		"3":  "Sur passage piéton - Sans signalisation lumineuse",
+		"4":  "Sur passage piéton - Avec signalisation lumineuse",
+		"5":  "Sur trottoir",
+		"6":  "Sur accotement",
+		"7":  "Sur refuge ou BAU",
+		"8":  "Sur contre allée",
+		"9":  "Inconnue",
+	},
+	"actp": {
+		"-1": "Non renseigné",
+		"0":  "Non renseigné ou sans objet",
+		"1":  "Sens véhicule heurtant",
+		"2":  "Sens inverse du véhicule",
+		"3":  "Traversant",
+		"4":  "Masqué",
+		"5":  "Jouant - courant",
+		"6":  "Avec animal",
+		"9":  "Autre",
+		"A":  "Monte/descend du véhicule",
+		"B":  "Inconnue",
+	},
+	"etatp": {
+		"-1": "Non renseigné",
+		"1":  "Seul",
+		"2":  "Accompagné",
+		"3":  "En groupe",
+	},
+}
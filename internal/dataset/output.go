@@ -0,0 +1,57 @@
+package dataset
+
+import (
+	"os"
+	"time"
+)
+
+// Options for writing rows of output. Some only apply to CSV files.
+type OutputOpts struct {
+	RawCodes     bool     // Write official codes and labels instead of values derived from them
+	Delimiter    rune     // CSV only: the field delimiter, or 0 for a comma
+	Bom          bool     // CSV only: start the file with a UTF-8 byte order mark, for Excel
+	DecimalPoint bool     // Use a point rather than a comma as the decimal separator in coordinates
+	Columns      []string // The names or headings of the columns to write, in order, or nil for all columns
+}
+
+// Creates the file at path, or returns standard output if path is nil. The
+// returned function closes the file.
+func createOutput(path *string) (*os.File, func(), error) {
+	if path == nil {
+		return os.Stdout, func() {}, nil
+	}
+
+	file, err := os.Create(*path)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return file, func() { file.Close() }, nil
+}
+
+// A row of output that can be given a short title, e.g. as the name of a point on a map.
+type Titré interface {
+	Titre() string
+}
+
+// A row of output that describes a person whose injuries have a severity.
+type AvecGravité interface {
+	NiveauDeGravité() Gravité
+}
+
+// A date and time in the format YYYY-MM-DDTHH:MM, in local time.
+type Horodatage string
+
+// Parses the date and time. Since the time zone isn't known, it's treated as UTC.
+func (horodatage Horodatage) Time() (time.Time, error) {
+	return time.Parse("2006-01-02T15:04", string(horodatage))
+}
+
+// A French-formatted latitude or longitude, with a comma as the decimal separator.
+type Coordonnée string
+
+// A row of output that belongs to a category, which is used to summarise rows.
+type Catégorisé interface {
+	Catégorie() string
+}
@@ -0,0 +1,227 @@
+package dataset
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// The type of the values in a column of output, which determines how they are
+// written in each format.
+type TypeColonne int
+
+const (
+	TypeTexte      TypeColonne = iota // A string
+	TypeCatégorie                     // A value with a label, such as Gravité
+	TypeEntier                        // An int
+	TypeNombre                        // A Nombre, which is empty if unspecified
+	TypeHorodatage                    // A Horodatage
+	TypeCoordonnée                    // A Coordonnée
+)
+
+// A column of output.
+type Colonne struct {
+	Nom         string                                         // A name without spaces or accents, e.g. "gravite"
+	Libellé     string                                         // The heading of the column, e.g. "Gravité"
+	Type        TypeColonne                                    // The type of the values returned by Valeur
+	Optionnelle bool                                           // True if the column can be empty
+	Valeur      func(ligne any) any                            // Returns the value of the column in a row
+	Format      func(valeur any, outputOpts OutputOpts) string // Formats a value as text
+
+	// If the values are derived from official codes, returns the code in a row.
+	codeOfficiel func(ligne any) CodeOfficiel
+}
+
+func ColonneTexte[L any](libellé string, valeur func(ligne L) string) Colonne {
+	return newColonne(libellé, TypeTexte, false, valeur)
+}
+
+func ColonneEntier[L any](libellé string, valeur func(ligne L) int) Colonne {
+	return newColonne(libellé, TypeEntier, false, valeur)
+}
+
+func ColonneNombre[L any](libellé string, valeur func(ligne L) Nombre) Colonne {
+	return newColonne(libellé, TypeNombre, true, valeur)
+}
+
+func ColonneHorodatage[L any](libellé string, valeur func(ligne L) Horodatage) Colonne {
+	return newColonne(libellé, TypeHorodatage, true, valeur)
+}
+
+func ColonneCoordonnée[L any](libellé string, valeur func(ligne L) Coordonnée) Colonne {
+	colonne := newColonne(libellé, TypeCoordonnée, true, valeur)
+	colonne.Format = formatCoordonnée
+	return colonne
+}
+
+// A column of values that have labels. If the values remember the official codes they
+// were derived from, the column can be replaced by the codes and their labels.
+func ColonneCatégorie[L any, V fmt.Stringer](libellé string, valeur func(ligne L) V) Colonne {
+	colonne := newColonne(libellé, TypeCatégorie, true, valeur)
+
+	if _, ok := any(*new(V)).(AvecCodeOfficiel); ok {
+		colonne.codeOfficiel = func(ligne any) CodeOfficiel {
+			return any(valeur(ligne.(L))).(AvecCodeOfficiel).CodeOfficiel()
+		}
+	}
+
+	return colonne
+}
+
+func newColonne[L any, V any](libellé string, typeColonne TypeColonne, optionnelle bool, valeur func(ligne L) V) Colonne {
+	return Colonne{
+		Nom:         nomDeColonne(libellé),
+		Libellé:     libellé,
+		Type:        typeColonne,
+		Optionnelle: optionnelle,
+		Valeur:      func(ligne any) any { return valeur(ligne.(L)) },
+		Format:      formatValeur,
+	}
+}
+
+func formatValeur(valeur any, outputOpts OutputOpts) string {
+	return fmt.Sprint(valeur)
+}
+
+func formatCoordonnée(valeur any, outputOpts OutputOpts) string {
+	if outputOpts.DecimalPoint {
+		return strings.Replace(string(valeur.(Coordonnée)), ",", ".", 1)
+	}
+
+	return string(valeur.(Coordonnée))
+}
+
+var supprimerAccents = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// Converts a heading to a name in lower case, without accents, and with underscores
+// instead of spaces, e.g. "Catégorie de personne" becomes "categorie_de_personne".
+func nomDeColonne(libellé string) string {
+	nom, _, err := transform.String(supprimerAccents, strings.ReplaceAll(strings.ToLower(libellé), "œ", "oe"))
+
+	if err != nil {
+		nom = libellé
+	}
+
+	return strings.Join(strings.FieldsFunc(nom, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "_")
+}
+
+// Returns the value of the column in a row as text.
+func (colonne Colonne) Texte(ligne any, outputOpts OutputOpts) string {
+	return colonne.Format(colonne.Valeur(ligne), outputOpts)
+}
+
+// Returns the value of the column in a row as nil (if the column is optional and
+// the value is empty), a string, an int64, a float64 or a time.Time, for formats
+// that have typed values.
+func (colonne Colonne) valeurTypée(ligne any) any {
+	valeur := colonne.Valeur(ligne)
+
+	switch colonne.Type {
+	case TypeEntier:
+		return int64(valeur.(int))
+	case TypeNombre:
+		if nombre := valeur.(Nombre); nombre != 0 {
+			return int64(nombre)
+		}
+	case TypeHorodatage:
+		if t, err := valeur.(Horodatage).Time(); err == nil {
+			return t
+		}
+	case TypeCoordonnée:
+		if coordonnée, err := parseCoordonnée(string(valeur.(Coordonnée))); err == nil {
+			return coordonnée
+		}
+	default:
+		if texte := colonne.Format(valeur, OutputOpts{}); texte != "" || !colonne.Optionnelle {
+			return texte
+		}
+	}
+
+	return nil
+}
+
+// Rows of output, and the columns that can be written for them.
+type Tableau struct {
+	Colonnes []Colonne
+	Lignes   []any
+}
+
+func NewTableau[L any](colonnes []Colonne, lignes []L) Tableau {
+	return Tableau{Colonnes: colonnes, Lignes: ToSliceOfAny(lignes)}
+}
+
+// Returns the columns to write. If raw codes are requested, each column of values
+// derived from official codes is replaced by two columns containing the code and its
+// label. If columns were selected, by name or by heading, only those are returned,
+// in the order in which they were selected.
+func (tableau Tableau) ColonnesChoisies(outputOpts OutputOpts) ([]Colonne, error) {
+	var colonnes []Colonne
+
+	for _, colonne := range tableau.Colonnes {
+		if outputOpts.RawCodes && colonne.codeOfficiel != nil {
+			colonnes = append(colonnes, colonnesCodeOfficiel(colonne)...)
+		} else {
+			colonnes = append(colonnes, colonne)
+		}
+	}
+
+	if outputOpts.Columns == nil {
+		return colonnes, nil
+	}
+
+	var colonnesChoisies []Colonne
+
+	for _, nomChoisi := range outputOpts.Columns {
+		nomChoisi = strings.TrimSpace(nomChoisi)
+		trouvée := false
+
+		for _, colonne := range colonnes {
+			if strings.EqualFold(colonne.Nom, nomChoisi) || strings.EqualFold(colonne.Libellé, nomChoisi) {
+				colonnesChoisies = append(colonnesChoisies, colonne)
+				trouvée = true
+				break
+			}
+		}
+
+		if !trouvée {
+			var noms []string
+
+			for _, colonne := range colonnes {
+				noms = append(noms, colonne.Nom)
+			}
+
+			return nil, fmt.Errorf("no column '%v' (available columns: %v)", nomChoisi, strings.Join(noms, ", "))
+		}
+	}
+
+	return colonnesChoisies, nil
+}
+
+// Returns two columns containing the official code from which the values of a
+// column were derived, and the label of the code.
+func colonnesCodeOfficiel(colonne Colonne) []Colonne {
+	return []Colonne{
+		{
+			Nom:         colonne.Nom + "_code",
+			Libellé:     colonne.Libellé + " (code)",
+			Type:        TypeCatégorie,
+			Optionnelle: true,
+			Valeur:      func(ligne any) any { return colonne.codeOfficiel(ligne).Code },
+			Format:      formatValeur,
+		},
+		{
+			Nom:         colonne.Nom,
+			Libellé:     colonne.Libellé,
+			Type:        TypeCatégorie,
+			Optionnelle: true,
+			Valeur:      func(ligne any) any { return colonne.codeOfficiel(ligne).Libellé },
+			Format:      formatValeur,
+		},
+	}
+}
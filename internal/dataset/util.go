@@ -1,5 +1,7 @@
 package dataset
 
+import "fmt"
+
 func Filter[T any](slice []T, include func(T) bool) []T {
 	var result []T
 
@@ -21,3 +23,17 @@ func ToSliceOfAny[T any](in []T) []any {
 
 	return out
 }
+
+// Returns a function that makes identifiers for users in files that don't have them.
+// Each identifier is made from the accident identifier, the vehicle letter and the
+// position of the user among the users of that vehicle in the file, so it is the
+// same each time the file is read.
+func makeIdUsagerSynthesiser() func(idAccident string, numVéhicule string) string {
+	counts := make(map[string]int)
+
+	return func(idAccident string, numVéhicule string) string {
+		key := fmt.Sprintf("%v-%v", idAccident, numVéhicule)
+		counts[key]++
+		return fmt.Sprintf("%v-%v", key, counts[key])
+	}
+}
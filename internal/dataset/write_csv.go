@@ -2,43 +2,46 @@ package dataset
 
 import (
 	"encoding/csv"
-	"fmt"
-	"os"
-	"reflect"
-	"regexp"
-	"strings"
-	"unicode"
 )
 
-func WriteCsv(objs []any, path *string) error {
-	if len(objs) == 0 {
+func WriteCsv(tableau Tableau, path *string, outputOpts OutputOpts) error {
+	if len(tableau.Lignes) == 0 {
 		return nil
 	}
 
-	var file *os.File
-	var err error
+	colonnes, err := tableau.ColonnesChoisies(outputOpts)
 
-	if path != nil {
-		file, err = os.Create(*path)
+	if err != nil {
+		return err
+	}
+
+	file, closeFile, err := createOutput(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer closeFile()
 
-		if err != nil {
+	if outputOpts.Bom {
+		if _, err := file.WriteString("\uFEFF"); err != nil {
 			return err
 		}
-
-		defer file.Close()
-	} else {
-		file = os.Stdout
 	}
 
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
-	if err := writer.Write(toCsvHeader(objs[0])); err != nil {
+	if outputOpts.Delimiter != 0 {
+		writer.Comma = outputOpts.Delimiter
+	}
+
+	if err := writer.Write(toCsvHeader(colonnes)); err != nil {
 		return err
 	}
 
-	for _, obj := range objs {
-		if err := writer.Write(toCsvRow(obj)); err != nil {
+	for _, ligne := range tableau.Lignes {
+		if err := writer.Write(toCsvRow(colonnes, ligne, outputOpts)); err != nil {
 			return err
 		}
 	}
@@ -46,38 +49,22 @@ func WriteCsv(objs []any, path *string) error {
 	return nil
 }
 
-func toCsvHeader(obj any) []string {
+func toCsvHeader(colonnes []Colonne) []string {
 	var header []string
-	value := reflect.ValueOf(obj)
-	objType := value.Type()
 
-	for index := 0; index < value.NumField(); index++ {
-		header = append(header, camelCaseToHeading(objType.Field(index).Name))
+	for _, colonne := range colonnes {
+		header = append(header, colonne.Libellé)
 	}
 
 	return header
 }
 
-func toCsvRow(obj any) []string {
+func toCsvRow(colonnes []Colonne, ligne any, outputOpts OutputOpts) []string {
 	var row []string
-	value := reflect.ValueOf(obj)
 
-	for index := 0; index < value.NumField(); index++ {
-		row = append(row, fmt.Sprint(value.Field(index).Interface()))
+	for _, colonne := range colonnes {
+		row = append(row, colonne.Texte(ligne, outputOpts))
 	}
 
 	return row
 }
-
-func camelCaseToHeading(str string) string {
-	if len(str) == 1 {
-		return strings.ToUpper(str)
-	}
-
-	re := regexp.MustCompile(`([A-Z])`)
-	str = re.ReplaceAllString(str, ` $1`)
-	str = strings.Trim(str, " ")
-	lowerCaseStr := strings.ToLower(str)
-	runes := []rune(lowerCaseStr)
-	return string(append([]rune{unicode.ToUpper(runes[0])}, runes[1:]...))
-}
@@ -0,0 +1,120 @@
+package dataset
+
+import (
+	"bufio"
+	"encoding/json"
+	"time"
+)
+
+// Writes a GeoJSON FeatureCollection containing a Point feature for each row
+// that has a position (see Localisé), with the row's columns as properties.
+// Rows without a position are skipped, and the number skipped is returned.
+func WriteGeoJson(tableau Tableau, path *string, outputOpts OutputOpts) (int, error) {
+	colonnes, err := tableau.ColonnesChoisies(outputOpts)
+
+	if err != nil {
+		return 0, err
+	}
+
+	file, closeFile, err := createOutput(path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeFile()
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+
+	positions, skipped := positionsOf(tableau.Lignes)
+
+	if _, err := writer.WriteString(`{"type":"FeatureCollection","features":[`); err != nil {
+		return skipped, err
+	}
+
+	first := true
+
+	for index, ligne := range tableau.Lignes {
+		position, ok := positions[index]
+
+		if !ok {
+			continue
+		}
+
+		if !first {
+			if err := writer.WriteByte(','); err != nil {
+				return skipped, err
+			}
+		}
+
+		first = false
+
+		feature, err := toGeoJsonFeature(colonnes, ligne, position)
+
+		if err != nil {
+			return skipped, err
+		}
+
+		if _, err := writer.Write(feature); err != nil {
+			return skipped, err
+		}
+	}
+
+	if _, err := writer.WriteString("]}\n"); err != nil {
+		return skipped, err
+	}
+
+	return skipped, nil
+}
+
+// GeoJSON coordinates are in the order longitude, latitude.
+func toGeoJsonFeature(colonnes []Colonne, ligne any, position [2]float64) ([]byte, error) {
+	coordinates, err := json.Marshal([]float64{position[1], position[0]})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var feature []byte
+	feature = append(feature, `{"type":"Feature","geometry":{"type":"Point","coordinates":`...)
+	feature = append(feature, coordinates...)
+	feature = append(feature, `},"properties":{`...)
+
+	for index, colonne := range colonnes {
+		if index > 0 {
+			feature = append(feature, ',')
+		}
+
+		feature, err = appendJsonProperty(feature, colonne.Libellé, colonne.valeurTypée(ligne))
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(feature, "}}"...), nil
+}
+
+// Appends a property to a JSON object, preserving the order of the columns.
+func appendJsonProperty(properties []byte, name string, value any) ([]byte, error) {
+	nameJson, err := json.Marshal(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if t, ok := value.(time.Time); ok {
+		value = t.Format("2006-01-02T15:04")
+	}
+
+	valueJson, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	properties = append(properties, nameJson...)
+	properties = append(properties, ':')
+	return append(properties, valueJson...), nil
+}
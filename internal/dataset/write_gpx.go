@@ -0,0 +1,94 @@
+package dataset
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type gpx struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Xmlns     string        `xml:"xmlns,attr"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+}
+
+type gpxWaypoint struct {
+	Latitude    float64 `xml:"lat,attr"`
+	Longitude   float64 `xml:"lon,attr"`
+	Name        string  `xml:"name"`
+	Description string  `xml:"desc"`
+	Type        string  `xml:"type,omitempty"`
+}
+
+// Writes a GPX file containing a waypoint for each row that has a position
+// (see Localisé), with the row's columns in the waypoint's description. If the
+// rows implement AvecGravité, the severity of each person's injuries is used as
+// the waypoint's type. Rows without a position are skipped, and the number skipped
+// is returned.
+func WriteGpx(tableau Tableau, path *string, outputOpts OutputOpts) (int, error) {
+	colonnes, err := tableau.ColonnesChoisies(outputOpts)
+
+	if err != nil {
+		return 0, err
+	}
+
+	file, closeFile, err := createOutput(path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeFile()
+
+	positions, skipped := positionsOf(tableau.Lignes)
+	document := gpx{Xmlns: "http://www.topografix.com/GPX/1/1", Version: "1.1", Creator: "accicalc"}
+
+	for index, ligne := range tableau.Lignes {
+		position, ok := positions[index]
+
+		if !ok {
+			continue
+		}
+
+		waypoint := gpxWaypoint{
+			Latitude:  position[0],
+			Longitude: position[1],
+			Name:      titreOf(ligne, index),
+		}
+
+		if avecGravité, ok := ligne.(AvecGravité); ok {
+			waypoint.Type = avecGravité.NiveauDeGravité().String()
+		}
+
+		var description []string
+
+		for _, colonne := range colonnes {
+			if texte := colonne.Texte(ligne, outputOpts); texte != "" {
+				description = append(description, fmt.Sprintf("%v: %v", colonne.Libellé, texte))
+			}
+		}
+
+		waypoint.Description = strings.Join(description, "\n")
+		document.Waypoints = append(document.Waypoints, waypoint)
+	}
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+
+	if _, err := writer.WriteString(xml.Header); err != nil {
+		return skipped, err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(document); err != nil {
+		return skipped, err
+	}
+
+	_, err = writer.WriteString("\n")
+	return skipped, err
+}
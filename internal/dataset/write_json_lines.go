@@ -0,0 +1,43 @@
+package dataset
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+)
+
+// Writes each object as a single line of JSON.
+func WriteJsonLines[T Jsonable](objs []T, path *string) error {
+	file, closeFile, err := createOutput(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer closeFile()
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+
+	for _, obj := range objs {
+		jsonStr, err := obj.AsJson()
+
+		if err != nil {
+			return err
+		}
+
+		var line bytes.Buffer
+
+		if err := json.Compact(&line, []byte(jsonStr)); err != nil {
+			return err
+		}
+
+		line.WriteByte('\n')
+
+		if _, err := writer.Write(line.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package dataset
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+)
+
+type kml struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Document kmlDocument `xml:"Document"`
+}
+
+type kmlDocument struct {
+	Name       string         `xml:"name"`
+	Styles     []kmlStyle     `xml:"Style"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlStyle struct {
+	Id    string `xml:"id,attr"`
+	Color string `xml:"IconStyle>color"`
+	Icon  string `xml:"IconStyle>Icon>href"`
+}
+
+type kmlPlacemark struct {
+	Name         string    `xml:"name"`
+	StyleUrl     string    `xml:"styleUrl,omitempty"`
+	ExtendedData []kmlData `xml:"ExtendedData>Data"`
+	Coordinates  string    `xml:"Point>coordinates"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+const kmlIcon = "http://maps.google.com/mapfiles/kml/shapes/placemark_circle.png"
+
+// KML colours are in the order alpha, blue, green, red.
+var kmlStyles = []kmlStyle{
+	{Id: kmlStyleId(GravitéNonRenseignée), Color: "ff9e9e9e", Icon: kmlIcon},
+	{Id: kmlStyleId(Indemne), Color: "ff50af4c", Icon: kmlIcon},
+	{Id: kmlStyleId(Tué), Color: "ff1c1cb7", Icon: kmlIcon},
+	{Id: kmlStyleId(BlesséHospitalisé), Color: "ff0098ff", Icon: kmlIcon},
+	{Id: kmlStyleId(BlesséLéger), Color: "ff3bebff", Icon: kmlIcon},
+}
+
+func kmlStyleId(gravité Gravité) string {
+	return fmt.Sprintf("gravite-%d", gravité)
+}
+
+// Writes a KML document containing a placemark for each row that has a position
+// (see Localisé), with the row's columns as extended data. If the rows implement
+// AvecGravité, placemarks are coloured according to the severity of each person's
+// injuries. Rows without a position are skipped, and the number skipped is returned.
+func WriteKml(tableau Tableau, path *string, outputOpts OutputOpts) (int, error) {
+	colonnes, err := tableau.ColonnesChoisies(outputOpts)
+
+	if err != nil {
+		return 0, err
+	}
+
+	file, closeFile, err := createOutput(path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer closeFile()
+
+	positions, skipped := positionsOf(tableau.Lignes)
+	document := kmlDocument{Name: "accicalc", Styles: kmlStyles}
+
+	for index, ligne := range tableau.Lignes {
+		position, ok := positions[index]
+
+		if !ok {
+			continue
+		}
+
+		placemark := kmlPlacemark{
+			Name:        titreOf(ligne, index),
+			Coordinates: fmt.Sprintf("%v,%v", position[1], position[0]),
+		}
+
+		if avecGravité, ok := ligne.(AvecGravité); ok {
+			placemark.StyleUrl = "#" + kmlStyleId(avecGravité.NiveauDeGravité())
+		}
+
+		for _, colonne := range colonnes {
+			placemark.ExtendedData = append(placemark.ExtendedData,
+				kmlData{Name: colonne.Libellé, Value: colonne.Texte(ligne, outputOpts)},
+			)
+		}
+
+		document.Placemarks = append(document.Placemarks, placemark)
+	}
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+
+	if _, err := writer.WriteString(xml.Header); err != nil {
+		return skipped, err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(kml{Xmlns: "http://www.opengis.net/kml/2.2", Document: document}); err != nil {
+		return skipped, err
+	}
+
+	_, err = writer.WriteString("\n")
+	return skipped, err
+}
+
+// Returns the title of a row if it has one, otherwise its number.
+func titreOf(ligne any, index int) string {
+	if titré, ok := ligne.(Titré); ok {
+		return titré.Titre()
+	}
+
+	return fmt.Sprint(index + 1)
+}
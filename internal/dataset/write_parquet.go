@@ -0,0 +1,164 @@
+package dataset
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// Writes rows to a Parquet file, with the same columns as WriteCsv, in the same
+// order. Dates are written as local timestamps, coordinates as doubles, integers as
+// integers, and categories as dictionary-encoded strings. Empty values in optional
+// columns are written as nulls.
+func WriteParquet(tableau Tableau, path *string, outputOpts OutputOpts) error {
+	if len(tableau.Lignes) == 0 {
+		return nil
+	}
+
+	colonnes, err := tableau.ColonnesChoisies(outputOpts)
+
+	if err != nil {
+		return err
+	}
+
+	groupe := groupeOrdonné{Group: make(parquet.Group)}
+
+	for _, colonne := range colonnes {
+		if _, exists := groupe.Group[colonne.Libellé]; exists {
+			return fmt.Errorf("duplicate column '%v'", colonne.Libellé)
+		}
+
+		node := toParquetNode(colonne)
+		groupe.Group[colonne.Libellé] = node
+		groupe.champs = append(groupe.champs, champParquet{Node: node, nom: colonne.Libellé})
+	}
+
+	schema := parquet.NewSchema("accicalc", groupe)
+
+	file, closeFile, err := createOutput(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer closeFile()
+
+	writer := parquet.NewWriter(file, schema)
+
+	for _, ligne := range tableau.Lignes {
+		row := make(parquet.Row, len(colonnes))
+
+		for index, colonne := range colonnes {
+			value := toParquetValue(colonne.valeurTypée(ligne))
+			definitionLevel := 0
+
+			if !value.IsNull() && colonne.Optionnelle {
+				definitionLevel = 1
+			}
+
+			row[index] = value.Level(0, definitionLevel, index)
+		}
+
+		if _, err := writer.WriteRows([]parquet.Row{row}); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// A group whose fields are in the order in which they were added. The fields of a
+// parquet.Group are sorted by name.
+type groupeOrdonné struct {
+	parquet.Group
+	champs []parquet.Field
+}
+
+func (groupe groupeOrdonné) String() string {
+	var builder strings.Builder
+	_ = parquet.PrintSchema(&builder, "", groupe)
+	return builder.String()
+}
+
+func (groupe groupeOrdonné) Fields() []parquet.Field {
+	return groupe.champs
+}
+
+type champParquet struct {
+	parquet.Node
+	nom string
+}
+
+func (champ champParquet) Name() string {
+	return champ.nom
+}
+
+func (champ champParquet) Value(base reflect.Value) reflect.Value {
+	return base.MapIndex(reflect.ValueOf(champ.nom))
+}
+
+func toParquetNode(colonne Colonne) parquet.Node {
+	var node parquet.Node
+
+	switch colonne.Type {
+	case TypeEntier, TypeNombre:
+		node = parquet.Int(64)
+	case TypeHorodatage:
+		node = parquet.Leaf(horodatageLocal{parquet.Timestamp(parquet.Millisecond).Type()})
+	case TypeCoordonnée:
+		node = parquet.Leaf(parquet.DoubleType)
+	case TypeCatégorie:
+		node = parquet.Encoded(parquet.String(), &parquet.RLEDictionary)
+	default:
+		node = parquet.String()
+	}
+
+	if colonne.Optionnelle {
+		node = parquet.Optional(node)
+	}
+
+	return node
+}
+
+// A timestamp type with isAdjustedToUTC set to false. The data files give local
+// times without a time zone, so the timestamps are written as local times, which
+// readers must not convert from UTC. parquet.Timestamp only makes UTC timestamps.
+type horodatageLocal struct {
+	parquet.Type
+}
+
+func (horodatageLocal) String() string {
+	return "TIMESTAMP(isAdjustedToUTC=false,unit=MILLIS)"
+}
+
+func (horodatageLocal) LogicalType() *format.LogicalType {
+	return &format.LogicalType{Timestamp: &format.TimestampType{
+		IsAdjustedToUTC: false,
+		Unit:            format.TimeUnit{Millis: &format.MilliSeconds{}},
+	}}
+}
+
+// The deprecated converted types can only describe UTC timestamps.
+func (horodatageLocal) ConvertedType() *deprecated.ConvertedType {
+	return nil
+}
+
+func toParquetValue(valeur any) parquet.Value {
+	switch valeur := valeur.(type) {
+	case int64:
+		return parquet.Int64Value(valeur)
+	case float64:
+		return parquet.DoubleValue(valeur)
+	case time.Time:
+		return parquet.Int64Value(valeur.UnixMilli())
+	case string:
+		return parquet.ByteArrayValue([]byte(valeur))
+	default:
+		return parquet.NullValue()
+	}
+}
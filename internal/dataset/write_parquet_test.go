@@ -0,0 +1,94 @@
+package dataset
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type ligneDeTest struct {
+	commune  string
+	victimes int
+	date     Horodatage
+}
+
+var colonnesDeTest = []Colonne{
+	ColonneTexte("Commune", func(ligne ligneDeTest) string { return ligne.commune }),
+	ColonneEntier("Victimes", func(ligne ligneDeTest) int { return ligne.victimes }),
+	ColonneHorodatage("Date", func(ligne ligneDeTest) Horodatage { return ligne.date }),
+}
+
+func TestParquetColumnOrder(t *testing.T) {
+	tableau := NewTableau(colonnesDeTest, []ligneDeTest{
+		{"Paris", 2, "2019-03-01T08:15"},
+		{"Lyon", 1, "2019-07-14T22:40"},
+	})
+
+	for _, test := range []struct {
+		name     string
+		columns  []string
+		expected []string
+	}{
+		{"schema order", nil, []string{"Commune", "Victimes", "Date"}},
+		{"chosen order", []string{"victimes", "date", "commune"}, []string{"Victimes", "Date", "Commune"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.parquet")
+
+			if err := WriteParquet(tableau, &path, OutputOpts{Columns: test.columns}); err != nil {
+				t.Fatal(err)
+			}
+
+			file, err := os.Open(path)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer file.Close()
+			info, err := file.Stat()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parquetFile, err := parquet.OpenFile(file, info.Size())
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+
+			for _, field := range parquetFile.Schema().Fields() {
+				names = append(names, field.Name())
+			}
+
+			if !slices.Equal(names, test.expected) {
+				t.Fatalf("expected columns %v, got %v", test.expected, names)
+			}
+
+			rows := make([]parquet.Row, 2)
+			count, _ := parquetFile.RowGroups()[0].Rows().ReadRows(rows)
+
+			if count != 2 {
+				t.Fatalf("expected 2 rows, got %v", count)
+			}
+
+			victimes := slices.Index(names, "Victimes")
+
+			if value := rows[1][victimes].Int64(); value != 1 {
+				t.Errorf("expected 1 victim in the second row, got %v", value)
+			}
+
+			commune := slices.Index(names, "Commune")
+
+			if value := rows[1][commune].String(); value != "Lyon" {
+				t.Errorf("expected Lyon in the second row, got %v", value)
+			}
+		})
+	}
+}
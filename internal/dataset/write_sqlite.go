@@ -0,0 +1,409 @@
+package dataset
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+// Official columns whose codes are stored in each table, in addition to the
+// simplified categories.
+var (
+	colonnesOfficiellesAccidents = []string{"lum", "agg", "int", "atm", "col"}
+	colonnesOfficiellesLieux     = []string{"catr", "circ", "vosp", "prof", "plan", "surf", "infra", "situ"}
+	colonnesOfficiellesVéhicules = []string{"catv", "senc", "obs", "obsm", "choc", "manv"}
+	colonnesOfficiellesUsagers   = []string{"catu", "grav", "sexe", "trajet", "place", "locp", "actp", "etatp"}
+)
+
+// A column of a table, other than the columns containing official codes.
+type colonneSqlite struct {
+	nom        string
+	définition string
+}
+
+var (
+	colonnesSqliteAccidents = []colonneSqlite{
+		{"id_accident", "TEXT PRIMARY KEY"},
+		{"date", "TEXT NOT NULL"},
+		{"annee", "INTEGER NOT NULL"},
+		{"departement", "TEXT NOT NULL"},
+		{"commune", "INTEGER"},
+		{"adresse", "TEXT"},
+		{"latitude", "REAL"},
+		{"longitude", "REAL"},
+		{"luminosite", "TEXT"},
+		{"agglomeration", "TEXT"},
+		{"intersection", "TEXT"},
+		{"conditions_atmospheriques", "TEXT"},
+		{"type_collision", "TEXT"},
+	}
+
+	colonnesSqliteLieux = []colonneSqlite{
+		{"id_accident", "TEXT PRIMARY KEY REFERENCES accidents (id_accident)"},
+		{"categorie_route", "TEXT"},
+		{"regime_circulation", "TEXT"},
+		{"nombre_voies", "INTEGER"},
+		{"voie_speciale", "TEXT"},
+		{"profil", "TEXT"},
+		{"trace_en_plan", "TEXT"},
+		{"etat_surface", "TEXT"},
+		{"amenagement", "TEXT"},
+		{"situation", "TEXT"},
+		{"vitesse_maximale", "INTEGER"},
+	}
+
+	colonnesSqliteVéhicules = []colonneSqlite{
+		{"id_accident", "TEXT NOT NULL REFERENCES accidents (id_accident)"},
+		{"id_vehicule", "TEXT NOT NULL"},
+		{"num_vehicule", "TEXT"},
+		{"categorie_vehicule", "TEXT"},
+		{"sens_circulation", "TEXT"},
+		{"obstacle_fixe", "TEXT"},
+		{"obstacle_mobile", "TEXT"},
+		{"point_de_choc", "TEXT"},
+		{"manoeuvre", "TEXT"},
+	}
+
+	colonnesSqliteUsagers = []colonneSqlite{
+		{"id_accident", "TEXT NOT NULL REFERENCES accidents (id_accident)"},
+		{"id_usager", "TEXT NOT NULL"},
+		{"id_vehicule", "TEXT"},
+		{"num_vehicule", "TEXT"},
+		{"categorie_usager", "TEXT"},
+		{"gravite", "TEXT"},
+		{"sexe", "TEXT"},
+		{"annee_naissance", "INTEGER"},
+		{"motif_trajet", "TEXT"},
+		{"place", "INTEGER"},
+		{"ceinture", "TEXT"},
+		{"casque", "TEXT"},
+		{"dispositif_enfants", "TEXT"},
+		{"equipement_reflechissant", "TEXT"},
+		{"airbag", "TEXT"},
+		{"gants", "TEXT"},
+		{"autre_equipement", "TEXT"},
+		{"localisation_pieton", "TEXT"},
+		{"action_pieton", "TEXT"},
+		{"etat_pieton", "TEXT"},
+	}
+
+	colonnesSqliteNomenclature = []colonneSqlite{
+		{"colonne", "TEXT NOT NULL"},
+		{"code", "TEXT NOT NULL"},
+		{"libelle", "TEXT NOT NULL"},
+	}
+)
+
+var sqliteSchema = strings.Join([]string{
+	createTable("accidents", colonnesSqliteAccidents, colonnesOfficiellesAccidents),
+	"CREATE INDEX accidents_departement_commune ON accidents (departement, commune);",
+	"CREATE INDEX accidents_date ON accidents (date);",
+	createTable("lieux", colonnesSqliteLieux, colonnesOfficiellesLieux),
+	createTable("vehicules", colonnesSqliteVéhicules, colonnesOfficiellesVéhicules,
+		"PRIMARY KEY (id_accident, id_vehicule)",
+	),
+	createTable("usagers", colonnesSqliteUsagers, colonnesOfficiellesUsagers,
+		"PRIMARY KEY (id_accident, id_usager)",
+		"FOREIGN KEY (id_accident, id_vehicule) REFERENCES vehicules (id_accident, id_vehicule)",
+	),
+	"CREATE INDEX usagers_vehicule ON usagers (id_accident, id_vehicule);",
+	createTable("nomenclature", colonnesSqliteNomenclature, nil,
+		"PRIMARY KEY (colonne, code)",
+	),
+}, "\n\n")
+
+// Returns a CREATE TABLE statement for a table with the given columns, followed
+// by a column for each official code, which is named after the column in the
+// official data files, and by the given table constraints.
+func createTable(table string, colonnes []colonneSqlite, colonnesOfficielles []string, contraintes ...string) string {
+	var définitions []string
+
+	for _, colonne := range colonnes {
+		définitions = append(définitions, fmt.Sprintf("%v %v", colonne.nom, colonne.définition))
+	}
+
+	for _, colonne := range colonnesOfficielles {
+		définitions = append(définitions, fmt.Sprintf("%v_code TEXT", colonne))
+	}
+
+	définitions = append(définitions, contraintes...)
+	return fmt.Sprintf("CREATE TABLE %v (\n\t%v\n);", table, strings.Join(définitions, ",\n\t"))
+}
+
+// Writes accidents to a new SQLite database, with a table for each type of
+// record, and a table containing the official nomenclature. Fails if the file
+// already exists.
+func WriteSqlite(accidents []*Accident, path string) (err error) {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%v already exists", path)
+	}
+
+	// Foreign keys are enabled in the DSN so that they apply to every connection
+	// in the pool, including the one used by the transaction.
+	db, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)")
+
+	if err != nil {
+		return err
+	}
+
+	// Don't leave a partly written database, which would prevent the next attempt.
+	defer func() {
+		closeErr := db.Close()
+
+		if err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	if err := insertAccidents(tx, accidents); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := insertNomenclature(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertAccidents(tx *sql.Tx, accidents []*Accident) error {
+	insertAccident, err := prepareInsert(tx, "accidents", colonnesSqliteAccidents, colonnesOfficiellesAccidents)
+
+	if err != nil {
+		return err
+	}
+
+	insertLieu, err := prepareInsert(tx, "lieux", colonnesSqliteLieux, colonnesOfficiellesLieux)
+
+	if err != nil {
+		return err
+	}
+
+	insertVéhicule, err := prepareInsert(tx, "vehicules", colonnesSqliteVéhicules, colonnesOfficiellesVéhicules)
+
+	if err != nil {
+		return err
+	}
+
+	insertUsager, err := prepareInsert(tx, "usagers", colonnesSqliteUsagers, colonnesOfficiellesUsagers)
+
+	if err != nil {
+		return err
+	}
+
+	for _, accident := range accidents {
+		année, _ := strconv.Atoi(accident.Date[0:4])
+		latitude, longitude, ok := ParsePosition(accident.Latitude, accident.Longitude)
+		var position [2]any
+
+		if ok {
+			position = [2]any{latitude, longitude}
+		}
+
+		var commune any
+
+		if accident.Commune != nil {
+			commune = *accident.Commune
+		}
+
+		_, err := insertAccident.Exec(append([]any{
+			accident.IdAccident,
+			accident.Date,
+			année,
+			accident.Département,
+			commune,
+			accident.Adresse,
+			position[0],
+			position[1],
+			accident.Luminosité.String(),
+			accident.Agglomération.String(),
+			accident.Intersection.String(),
+			accident.ConditionsAtmosphériques.String(),
+			accident.TypeCollision.String(),
+		}, codesOf(accident.CodesOfficiels, colonnesOfficiellesAccidents)...)...)
+
+		if err != nil {
+			return fmt.Errorf("can't insert accident %v: %w", accident.IdAccident, err)
+		}
+
+		if lieu := accident.Lieu; lieu != nil {
+			_, err := insertLieu.Exec(append([]any{
+				accident.IdAccident,
+				lieu.CatégorieRoute.String(),
+				lieu.RégimeCirculation.String(),
+				nombreOrNull(lieu.NombreVoies),
+				lieu.VoieSpéciale.String(),
+				lieu.Profil.String(),
+				lieu.TracéEnPlan.String(),
+				lieu.ÉtatSurface.String(),
+				lieu.Aménagement.String(),
+				lieu.Situation.String(),
+				nombreOrNull(lieu.VitesseMaximale),
+			}, codesOf(lieu.CodesOfficiels, colonnesOfficiellesLieux)...)...)
+
+			if err != nil {
+				return fmt.Errorf("can't insert place for accident %v: %w", accident.IdAccident, err)
+			}
+		}
+
+		for _, véhicule := range accident.Véhicules {
+			_, err := insertVéhicule.Exec(append([]any{
+				accident.IdAccident,
+				véhicule.IdVéhicule,
+				véhicule.NumVéhicule,
+				véhicule.CatégorieVéhicule.String(),
+				véhicule.SensCirculation.String(),
+				véhicule.ObstacleFixe.String(),
+				véhicule.ObstacleMobile.String(),
+				véhicule.PointDeChoc.String(),
+				véhicule.Manœuvre.String(),
+			}, codesOf(véhicule.CodesOfficiels, colonnesOfficiellesVéhicules)...)...)
+
+			if err != nil {
+				return fmt.Errorf("can't insert vehicle %v for accident %v: %w", véhicule.IdVéhicule, accident.IdAccident, err)
+			}
+
+			for _, usager := range véhicule.Usagers {
+				if err := insertUsagerRow(insertUsager, usager, véhicule.IdVéhicule); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, usager := range accident.AutresUsagers {
+			if err := insertUsagerRow(insertUsager, usager, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func insertUsagerRow(insertUsager *sql.Stmt, usager *Usager, idVéhicule any) error {
+	var annéeNaissance any
+
+	if usager.AnnéeNaissance != 0 {
+		annéeNaissance = usager.AnnéeNaissance
+	}
+
+	_, err := insertUsager.Exec(append([]any{
+		usager.IdAccident,
+		usager.IdUsager,
+		idVéhicule,
+		usager.NumVéhicule,
+		usager.CatégorieUsager.String(),
+		usager.Gravité.String(),
+		usager.Sexe.String(),
+		annéeNaissance,
+		usager.MotifTrajet.String(),
+		nombreOrNull(usager.Place),
+		usager.Équipements.Ceinture.String(),
+		usager.Équipements.Casque.String(),
+		usager.Équipements.DispositifEnfants.String(),
+		usager.Équipements.ÉquipementRéfléchissant.String(),
+		usager.Équipements.Airbag.String(),
+		usager.Équipements.Gants.String(),
+		usager.Équipements.AutreÉquipement.String(),
+		usager.LocalisationPiéton.String(),
+		usager.ActionPiéton.String(),
+		usager.ÉtatPiéton.String(),
+	}, codesOf(usager.CodesOfficiels, colonnesOfficiellesUsagers)...)...)
+
+	if err != nil {
+		return fmt.Errorf("can't insert user %v for accident %v: %w", usager.IdUsager, usager.IdAccident, err)
+	}
+
+	return nil
+}
+
+func insertNomenclature(tx *sql.Tx) error {
+	insert, err := prepareInsert(tx, "nomenclature", colonnesSqliteNomenclature, nil)
+
+	if err != nil {
+		return err
+	}
+
+	var colonnes []string
+
+	for colonne := range nomenclature {
+		colonnes = append(colonnes, colonne)
+	}
+
+	sort.Strings(colonnes)
+
+	for _, colonne := range colonnes {
+		for code, libellé := range nomenclature[colonne] {
+			if _, err := insert.Exec(colonne, code, libellé); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Prepares an INSERT statement for a table with the given columns followed by a
+// column for each official code.
+func prepareInsert(tx *sql.Tx, table string, colonnes []colonneSqlite, colonnesOfficielles []string) (*sql.Stmt, error) {
+	var noms, placeholders []string
+
+	for _, colonne := range colonnes {
+		noms = append(noms, colonne.nom)
+	}
+
+	for _, colonne := range colonnesOfficielles {
+		noms = append(noms, colonne+"_code")
+	}
+
+	for range noms {
+		placeholders = append(placeholders, "?")
+	}
+
+	return tx.Prepare(fmt.Sprintf("INSERT INTO %v (%v) VALUES (%v)",
+		table, strings.Join(noms, ", "), strings.Join(placeholders, ", ")))
+}
+
+// Returns the official codes in the specified columns, or NULL for missing codes.
+func codesOf(codesOfficiels CodesOfficiels, colonnesOfficielles []string) []any {
+	var codes []any
+
+	for _, colonne := range colonnesOfficielles {
+		if codeOfficiel, ok := codesOfficiels[colonne]; ok {
+			codes = append(codes, codeOfficiel.Code)
+		} else {
+			codes = append(codes, nil)
+		}
+	}
+
+	return codes
+}
+
+func nombreOrNull(nombre Nombre) any {
+	if nombre == 0 {
+		return nil
+	}
+
+	return int(nombre)
+}
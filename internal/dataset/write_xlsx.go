@@ -0,0 +1,300 @@
+package dataset
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	xlsxSummarySheet = "Résumé"
+	xlsxDataSheet    = "Données"
+	xlsxNoDateSheet  = "Date inconnue"
+)
+
+var xlsxGravités = []Gravité{Tué, BlesséHospitalisé, BlesséLéger, Indemne, GravitéNonRenseignée}
+
+// Writes rows to an Excel workbook, with the same columns as WriteCsv. If the rows
+// have a date, there is a sheet for each year, otherwise a single sheet. If the rows
+// implement AvecGravité, a summary sheet gives the number of people by year (and by
+// category, if the rows implement Catégorisé) and by severity.
+func WriteXlsx(tableau Tableau, path *string, outputOpts OutputOpts) error {
+	if len(tableau.Lignes) == 0 {
+		return nil
+	}
+
+	colonnes, err := tableau.ColonnesChoisies(outputOpts)
+
+	if err != nil {
+		return err
+	}
+
+	file, closeFile, err := createOutput(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer closeFile()
+
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+
+	styles, err := newXlsxStyles(workbook)
+
+	if err != nil {
+		return err
+	}
+
+	sheetNames, sheetRows := groupRowsBySheet(tableau)
+	firstSheet := workbook.GetSheetName(0)
+	_, summarise := tableau.Lignes[0].(AvecGravité)
+
+	if summarise {
+		if err := workbook.SetSheetName(firstSheet, xlsxSummarySheet); err != nil {
+			return err
+		}
+
+		if err := writeXlsxSummary(workbook, styles, sheetNames, sheetRows); err != nil {
+			return err
+		}
+	} else if err := workbook.SetSheetName(firstSheet, sheetNames[0]); err != nil {
+		return err
+	}
+
+	for _, sheetName := range sheetNames {
+		if _, err := workbook.NewSheet(sheetName); err != nil {
+			return err
+		}
+
+		if err := writeXlsxSheet(workbook, styles, sheetName, colonnes, sheetRows[sheetName]); err != nil {
+			return err
+		}
+	}
+
+	return workbook.Write(file)
+}
+
+type xlsxStyles struct {
+	header int
+	date   int
+}
+
+func newXlsxStyles(workbook *excelize.File) (xlsxStyles, error) {
+	header, err := workbook.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+
+	if err != nil {
+		return xlsxStyles{}, err
+	}
+
+	dateFormat := "yyyy-mm-dd hh:mm"
+	date, err := workbook.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+
+	if err != nil {
+		return xlsxStyles{}, err
+	}
+
+	return xlsxStyles{header: header, date: date}, nil
+}
+
+// Groups rows by the year of their first date column, if there is one. Returns
+// the names of the sheets in order, and the rows for each sheet.
+func groupRowsBySheet(tableau Tableau) ([]string, map[string][]any) {
+	var sheetNames []string
+	sheetRows := make(map[string][]any)
+	dateColumn := slices.IndexFunc(tableau.Colonnes, func(colonne Colonne) bool {
+		return colonne.Type == TypeHorodatage
+	})
+
+	for _, ligne := range tableau.Lignes {
+		sheetName := xlsxDataSheet
+
+		if dateColumn >= 0 {
+			if t, ok := tableau.Colonnes[dateColumn].valeurTypée(ligne).(time.Time); ok {
+				sheetName = fmt.Sprint(t.Year())
+			} else {
+				sheetName = xlsxNoDateSheet
+			}
+		}
+
+		if _, exists := sheetRows[sheetName]; !exists {
+			sheetNames = append(sheetNames, sheetName)
+		}
+
+		sheetRows[sheetName] = append(sheetRows[sheetName], ligne)
+	}
+
+	sort.Strings(sheetNames)
+	return sheetNames, sheetRows
+}
+
+func writeXlsxSheet(
+	workbook *excelize.File,
+	styles xlsxStyles,
+	sheetName string,
+	colonnes []Colonne,
+	lignes []any,
+) error {
+	streamWriter, err := workbook.NewStreamWriter(sheetName)
+
+	if err != nil {
+		return err
+	}
+
+	if err := streamWriter.SetPanes(&xlsxFrozenHeader); err != nil {
+		return err
+	}
+
+	if err := streamWriter.SetColWidth(1, len(colonnes), 18); err != nil {
+		return err
+	}
+
+	if err := streamWriter.SetRow("A1", toXlsxHeaderCells(toCsvHeader(colonnes), styles)); err != nil {
+		return err
+	}
+
+	for index, ligne := range lignes {
+		cellName, err := excelize.CoordinatesToCellName(1, index+2)
+
+		if err != nil {
+			return err
+		}
+
+		if err := streamWriter.SetRow(cellName, toXlsxCells(colonnes, ligne, styles)); err != nil {
+			return err
+		}
+	}
+
+	return streamWriter.Flush()
+}
+
+var xlsxFrozenHeader = excelize.Panes{
+	Freeze:      true,
+	YSplit:      1,
+	TopLeftCell: "A2",
+	ActivePane:  "bottomLeft",
+}
+
+func toXlsxHeaderCells(header []string, styles xlsxStyles) []any {
+	var cells []any
+
+	for _, heading := range header {
+		cells = append(cells, excelize.Cell{StyleID: styles.header, Value: heading})
+	}
+
+	return cells
+}
+
+// Converts a row to cells, keeping the type of each value.
+func toXlsxCells(colonnes []Colonne, ligne any, styles xlsxStyles) []any {
+	var cells []any
+
+	for _, colonne := range colonnes {
+		valeur := colonne.valeurTypée(ligne)
+
+		if t, ok := valeur.(time.Time); ok {
+			cells = append(cells, excelize.Cell{StyleID: styles.date, Value: t})
+		} else {
+			cells = append(cells, valeur)
+		}
+	}
+
+	return cells
+}
+
+func writeXlsxSummary(workbook *excelize.File, styles xlsxStyles, sheetNames []string, sheetRows map[string][]any) error {
+	streamWriter, err := workbook.NewStreamWriter(xlsxSummarySheet)
+
+	if err != nil {
+		return err
+	}
+
+	if err := streamWriter.SetPanes(&xlsxFrozenHeader); err != nil {
+		return err
+	}
+
+	_, byCatégorie := sheetRows[sheetNames[0]][0].(Catégorisé)
+	header := []string{"Année"}
+
+	if byCatégorie {
+		header = append(header, "Catégorie")
+	}
+
+	for _, gravité := range xlsxGravités {
+		header = append(header, gravité.String())
+	}
+
+	header = append(header, "Total")
+
+	if err := streamWriter.SetColWidth(1, len(header), 18); err != nil {
+		return err
+	}
+
+	if err := streamWriter.SetRow("A1", toXlsxHeaderCells(header, styles)); err != nil {
+		return err
+	}
+
+	rowNumber := 2
+
+	for _, sheetName := range sheetNames {
+		var catégories []string
+		counts := make(map[string]map[Gravité]int)
+
+		for _, obj := range sheetRows[sheetName] {
+			catégorie := ""
+
+			if catégorisé, ok := obj.(Catégorisé); ok {
+				catégorie = catégorisé.Catégorie()
+			}
+
+			if _, exists := counts[catégorie]; !exists {
+				catégories = append(catégories, catégorie)
+				counts[catégorie] = make(map[Gravité]int)
+			}
+
+			counts[catégorie][obj.(AvecGravité).NiveauDeGravité()]++
+		}
+
+		sort.Strings(catégories)
+
+		for _, catégorie := range catégories {
+			var cells []any
+
+			if année, err := strconv.Atoi(sheetName); err == nil {
+				cells = append(cells, année)
+			} else {
+				cells = append(cells, sheetName)
+			}
+
+			if byCatégorie {
+				cells = append(cells, catégorie)
+			}
+
+			total := 0
+
+			for _, gravité := range xlsxGravités {
+				cells = append(cells, counts[catégorie][gravité])
+				total += counts[catégorie][gravité]
+			}
+
+			cells = append(cells, total)
+			cellName, err := excelize.CoordinatesToCellName(1, rowNumber)
+
+			if err != nil {
+				return err
+			}
+
+			if err := streamWriter.SetRow(cellName, cells); err != nil {
+				return err
+			}
+
+			rowNumber++
+		}
+	}
+
+	return streamWriter.Flush()
+}
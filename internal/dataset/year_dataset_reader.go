@@ -1,10 +1,10 @@
 package dataset
 
 import (
+	"bufio"
 	"encoding/csv"
 	"fmt"
 	"io"
-	"os"
 	"sort"
 	"strings"
 
@@ -12,10 +12,10 @@ import (
 )
 
 type YearDatasetReader interface {
-	ReadCharacteristics(year uint, dataPath string) (accidents []*Accident, err error)
-	ReadPlaces(year uint, dataPath string) (places []*Lieu, err error)
-	ReadVehicles(year uint, dataPath string) (vehicles []*Véhicule, err error)
-	ReadUsers(year uint, dataPath string) (users []*Usager, err error)
+	ReadCharacteristics(year uint, emplacement Emplacement) (accidents []*Accident, err error)
+	ReadPlaces(year uint, emplacement Emplacement) (places []*Lieu, err error)
+	ReadVehicles(year uint, emplacement Emplacement) (vehicles []*Véhicule, err error)
+	ReadUsers(year uint, emplacement Emplacement) (users []*Usager, err error)
 }
 
 var (
@@ -48,11 +48,13 @@ func init() {
 	LastYear = Years[len(Years)-1]
 }
 
-func readCsvFile[T interface{}](path string, delimiter rune, convertRow func(row map[string]string) (*T, error)) ([]*T, error) {
+// Reads a data file, detecting its delimiter and character encoding unless they
+// have been specified for the year in FormatsDEntrée.
+func readCsvFile[T interface{}](emplacement Emplacement, year uint, convertRow func(row map[string]string) (*T, error)) ([]*T, error) {
 	var items []*T
 	var header []string
 
-	file, err := os.Open(path)
+	file, err := emplacement.Open()
 
 	if err != nil {
 		return nil, err
@@ -60,7 +62,19 @@ func readCsvFile[T interface{}](path string, delimiter rune, convertRow func(row
 
 	defer file.Close()
 
-	reader := csv.NewReader(file)
+	format := FormatsDEntrée[year]
+	decoded := bufio.NewReader(decode(file, format.Encodage))
+	delimiter := format.Délimiteur
+
+	if delimiter == 0 {
+		delimiter, err = detectDelimiter(decoded, emplacement.String())
+
+		if err != nil {
+			return nil, fmt.Errorf("%w%v", err, formatHint(year))
+		}
+	}
+
+	reader := csv.NewReader(decoded)
 	reader.Comma = delimiter
 	readHeader := true
 
@@ -72,14 +86,14 @@ func readCsvFile[T interface{}](path string, delimiter rune, convertRow func(row
 		}
 
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("can't read %v for %v: %w%v", emplacement, year, err, formatHint(year))
 		}
 
 		if readHeader {
 			lowerCaseRow := make([]string, len(row))
 
 			for index, columnName := range row {
-				lowerCaseRow[index] = strings.ToLower(columnName)
+				lowerCaseRow[index] = strings.ToLower(strings.TrimPrefix(columnName, "\uFEFF"))
 			}
 
 			header = append(header, lowerCaseRow...)
@@ -96,7 +110,8 @@ func readCsvFile[T interface{}](path string, delimiter rune, convertRow func(row
 		item, err := convertRow(rowMap)
 
 		if err != nil {
-			return nil, err
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %v of the data for %v: %w%v", line, year, err, formatHint(year))
 		}
 
 		items = append(items, item)
@@ -105,6 +120,17 @@ func readCsvFile[T interface{}](path string, delimiter rune, convertRow func(row
 	return items, nil
 }
 
+// Suggests how to override the detection of the format of the data files for a
+// year, which is the usual cause of errors in files that are otherwise readable.
+func formatHint(year uint) string {
+	return fmt.Sprintf(
+		" (if the delimiter or encoding of the files for %v wasn't detected correctly, use --input-delimiter %v=… or --input-encoding %v=…)",
+		year,
+		year,
+		year,
+	)
+}
+
 func readColumn(row map[string]string, columnName string, path string) (string, error) {
 	if maybeValue, ok := row[strings.ToLower(columnName)]; ok {
 		return maybeValue, nil
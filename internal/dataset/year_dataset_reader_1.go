@@ -2,31 +2,14 @@ package dataset
 
 import (
 	"fmt"
-	"path/filepath"
 	"strconv"
 	"strings"
 )
 
 type YearDatasetReader1 struct{}
 
-func filenameSuffix1(year uint) string {
-	if year <= 2016 {
-		return fmt.Sprintf("_%v.csv", year)
-	} else {
-		return fmt.Sprintf("-%v.csv", year)
-	}
-}
-
-func (*YearDatasetReader1) ReadCharacteristics(year uint, dataPath string) (accidents []*Accident, err error) {
-	var delimiter rune
-
-	if year == 2009 {
-		delimiter = '\t'
-	} else {
-		delimiter = ','
-	}
-
-	path := filepath.Join(dataPath, fmt.Sprint(year), fmt.Sprintf("caracteristiques%v", filenameSuffix1(year)))
+func (*YearDatasetReader1) ReadCharacteristics(year uint, emplacement Emplacement) (accidents []*Accident, err error) {
+	path := emplacement.String()
 
 	convertRow := func(row map[string]string) (*Accident, error) {
 		idAccident, err := readColumn(row, "Num_Acc", path)
@@ -205,23 +188,58 @@ func (*YearDatasetReader1) ReadCharacteristics(year uint, dataPath string) (acci
 
 		longitude := parseFixedPointLatLong(longitudeStr)
 
+		luminositéCode, err := readCode(row, "lum", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		agglomérationCode, err := readCode(row, "agg", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		intersectionCode, err := readCode(row, "int", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		conditionsAtmosphériquesCode, err := readCode(row, "atm", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		typeCollisionCode, err := readCode(row, "col", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
 		return &Accident{
-			IdAccident:  idAccident,
-			Date:        fmt.Sprintf("%04d-%02d-%02dT%v", correctedAnInt, moisInt, jourInt, isoTime),
-			Département: correctedDépartment,
-			Commune:     commune,
-			Adresse:     adresse,
-			Latitude:    latitude,
-			Longitude:   longitude,
+			IdAccident:               idAccident,
+			Date:                     fmt.Sprintf("%04d-%02d-%02dT%v", correctedAnInt, moisInt, jourInt, isoTime),
+			Département:              correctedDépartment,
+			Commune:                  commune,
+			Adresse:                  adresse,
+			Latitude:                 latitude,
+			Longitude:                longitude,
+			Luminosité:               parseLuminosité(luminositéCode),
+			Agglomération:            parseAgglomération(agglomérationCode),
+			Intersection:             parseIntersection(intersectionCode),
+			ConditionsAtmosphériques: parseConditionsAtmosphériques(conditionsAtmosphériquesCode),
+			TypeCollision:            parseTypeCollision(typeCollisionCode),
+			CodesOfficiels:           readCodesOfficiels(row, "lum", "agg", "int", "atm", "col"),
 		}, nil
 	}
 
-	return readCsvFile(path, delimiter, convertRow)
+	return readCsvFile(emplacement, year, convertRow)
 }
 
-func (*YearDatasetReader1) ReadPlaces(year uint, dataPath string) (places []*Lieu, err error) {
-	delimiter := ','
-	path := filepath.Join(dataPath, fmt.Sprint(year), fmt.Sprintf("lieux%v", filenameSuffix1(year)))
+func (*YearDatasetReader1) ReadPlaces(year uint, emplacement Emplacement) (places []*Lieu, err error) {
+	path := emplacement.String()
 
 	convertRow := func(row map[string]string) (*Lieu, error) {
 		idAccident, err := readColumn(row, "Num_Acc", path)
@@ -280,18 +298,92 @@ func (*YearDatasetReader1) ReadPlaces(year uint, dataPath string) (places []*Lie
 			}
 		}
 
+		catégorieRouteCode, err := readCode(row, "catr", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		régimeCirculationCode, err := readCode(row, "circ", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		nombreVoies, err := readNombre(row, "nbv", path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		profilCode, err := readCode(row, "prof", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tracéEnPlanCode, err := readCode(row, "plan", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		étatSurfaceCode, err := readCode(row, "surf", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		aménagementCode, err := readCode(row, "infra", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		situationCode, err := readCode(row, "situ", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		codesOfficiels := readCodesOfficiels(row, "catr", "circ", "vosp", "prof", "plan", "surf", "infra", "situ")
+
+		// Before 2019, 'infra' and 'situ' have no code for "Aucun", and 0 means that
+		// the column wasn't filled in.
+		aménagementCode = unspecifiedIfZero1(aménagementCode, "infra", codesOfficiels)
+		situationCode = unspecifiedIfZero1(situationCode, "situ", codesOfficiels)
+
 		return &Lieu{
-			IdAccident:   idAccident,
-			VoieSpéciale: voieSpéciale,
+			IdAccident:        idAccident,
+			CatégorieRoute:    parseCatégorieRoute(catégorieRouteCode),
+			RégimeCirculation: parseRégimeCirculation(régimeCirculationCode),
+			NombreVoies:       nombreVoies,
+			VoieSpéciale:      voieSpéciale,
+			Profil:            parseProfil(profilCode),
+			TracéEnPlan:       parseTracéEnPlan(tracéEnPlanCode),
+			ÉtatSurface:       parseÉtatSurface(étatSurfaceCode),
+			Aménagement:       parseAménagement(aménagementCode),
+			Situation:         parseSituation(situationCode),
+			CodesOfficiels:    codesOfficiels,
 		}, nil
 	}
 
-	return readCsvFile(path, delimiter, convertRow)
+	return readCsvFile(emplacement, year, convertRow)
+}
+
+// Treats the code 0 in a column as "Non renseigné", and labels the official code
+// accordingly.
+func unspecifiedIfZero1(code int, columnName string, codesOfficiels CodesOfficiels) int {
+	if code != 0 {
+		return code
+	}
+
+	codesOfficiels[columnName] = CodeOfficiel{Code: "0", Libellé: nomenclature[columnName]["-1"]}
+	return -1
 }
 
-func (*YearDatasetReader1) ReadVehicles(year uint, dataPath string) (vehicles []*Véhicule, err error) {
-	delimiter := ','
-	path := filepath.Join(dataPath, fmt.Sprint(year), fmt.Sprintf("vehicules%v", filenameSuffix1(year)))
+func (*YearDatasetReader1) ReadVehicles(year uint, emplacement Emplacement) (vehicles []*Véhicule, err error) {
+	path := emplacement.String()
 
 	convertRow := func(row map[string]string) (*Véhicule, error) {
 		idAccident, err := readColumn(row, "Num_Acc", path)
@@ -381,23 +473,81 @@ func (*YearDatasetReader1) ReadVehicles(year uint, dataPath string) (vehicles []
 		case 40:
 			catégorieVéhicule = Tramway
 
+		// 50 – EDP à moteur
+		case 50:
+			catégorieVéhicule = EDPÀMoteur
+
+		// 60 – EDP sans moteur
+		case 60:
+			catégorieVéhicule = EDPSansMoteur
+
+		// 80 – VAE
+		case 80:
+			catégorieVéhicule = VéloÀAssistanceÉlectrique
+
+		// 99 – Autre véhicule (in 2018, this is used for personal mobility devices, which were
+		// previously recorded as pedestrians)
+		case 99:
+			if year == 2018 {
+				catégorieVéhicule = EDPNonPrécisé
+			} else {
+				catégorieVéhicule = AutreVéhicule
+			}
+
 		default:
 			catégorieVéhicule = AutreVéhicule
 		}
 
+		sensCirculationCode, err := readCode(row, "senc", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		obstacleFixeCode, err := readCode(row, "obs", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		obstacleMobileCode, err := readCode(row, "obsm", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pointDeChocCode, err := readCode(row, "choc", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		manœuvreCode, err := readCode(row, "manv", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
 		return &Véhicule{
 			IdVéhicule:        idVéhicule,
+			NumVéhicule:       idVéhicule,
 			IdAccident:        idAccident,
 			CatégorieVéhicule: catégorieVéhicule,
+			SensCirculation:   parseSensCirculation(sensCirculationCode),
+			ObstacleFixe:      parseObstacleFixe(obstacleFixeCode),
+			ObstacleMobile:    parseObstacleMobile(obstacleMobileCode),
+			PointDeChoc:       parsePointDeChoc(pointDeChocCode),
+			Manœuvre:          parseManœuvre(manœuvreCode),
+			CodesOfficiels:    readCodesOfficiels(row, "catv", "senc", "obs", "obsm", "choc", "manv"),
 		}, nil
 	}
 
-	return readCsvFile(path, delimiter, convertRow)
+	return readCsvFile(emplacement, year, convertRow)
 }
 
-func (*YearDatasetReader1) ReadUsers(year uint, dataPath string) (users []*Usager, err error) {
-	delimiter := ','
-	path := filepath.Join(dataPath, fmt.Sprint(year), fmt.Sprintf("usagers%v", filenameSuffix1(year)))
+func (*YearDatasetReader1) ReadUsers(year uint, emplacement Emplacement) (users []*Usager, err error) {
+	path := emplacement.String()
+	synthesiseIdUsager := makeIdUsagerSynthesiser()
 
 	convertRow := func(row map[string]string) (*Usager, error) {
 		idAccident, err := readColumn(row, "Num_Acc", path)
@@ -433,10 +583,7 @@ func (*YearDatasetReader1) ReadUsers(year uint, dataPath string) (users []*Usage
 
 		switch catégorieUsagerInt {
 		// 1 – Conducteur
-		// 4 - Piéton en roller ou en trottinette (catégorie déplacée, à partir de l’année 2018, vers le fichier
-		// "Véhicules" Catégorie du véhicule : 99 - Autre véhicule. Cette catégorie est désormais considérée comme
-		// un véhicule : engin de déplacement personnel)
-		case 1, 4:
+		case 1:
 			catégorieUsager = Conducteur
 
 		// 2 – Passager
@@ -447,6 +594,12 @@ func (*YearDatasetReader1) ReadUsers(year uint, dataPath string) (users []*Usage
 		case 3:
 			catégorieUsager = Piéton
 
+		// 4 - Piéton en roller ou en trottinette (catégorie déplacée, à partir de l’année 2018, vers le fichier
+		// "Véhicules" Catégorie du véhicule : 99 - Autre véhicule. Cette catégorie est désormais considérée comme
+		// un véhicule : engin de déplacement personnel)
+		case 4:
+			catégorieUsager = PiétonEnRollerOuTrottinette
+
 		default:
 			return nil, fmt.Errorf(
 				"can't parse column 'catu' with value '%v' for accident %v in %v",
@@ -551,17 +704,111 @@ func (*YearDatasetReader1) ReadUsers(year uint, dataPath string) (users []*Usage
 			}
 		}
 
+		équipementsCode, err := readCode(row, "secu", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		motifTrajetCode, err := readCode(row, "trajet", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		place, err := readNombre(row, "place", path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		localisationPiétonCode, err := readCode(row, "locp", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		actionPiétonStr, err := readColumn(row, "actp", path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		étatPiétonCode, err := readCode(row, "etatp", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
 		return &Usager{
-			IdVéhicule:      idVéhicule,
-			IdAccident:      idAccident,
-			CatégorieUsager: catégorieUsager,
-			Sexe:            sexe,
-			Gravité:         gravité,
-			AnnéeNaissance:  annéeNaissance,
+			IdUsager:           synthesiseIdUsager(idAccident, idVéhicule),
+			IdVéhicule:         idVéhicule,
+			NumVéhicule:        idVéhicule,
+			IdAccident:         idAccident,
+			CatégorieUsager:    catégorieUsager,
+			Sexe:               sexe,
+			Gravité:            gravité,
+			AnnéeNaissance:     annéeNaissance,
+			MotifTrajet:        parseMotifTrajet(motifTrajetCode),
+			Place:              parsePlace(place),
+			Équipements:        parseÉquipements1(équipementsCode),
+			LocalisationPiéton: parseLocalisationPiéton(localisationPiétonCode),
+			ActionPiéton:       parseActionPiéton(actionPiétonStr),
+			ÉtatPiéton:         parseÉtatPiéton(étatPiétonCode),
+			CodesOfficiels:     readCodesOfficiels(row, "catu", "grav", "sexe", "trajet", "place", "locp", "actp", "etatp"),
 		}, nil
 	}
 
-	return readCsvFile(path, delimiter, convertRow)
+	return readCsvFile(emplacement, year, convertRow)
+}
+
+// Before 2019, the first digit of the 'secu' column is a type of equipment, and
+// the second digit says whether it was used.
+func parseÉquipements1(code int) Équipements {
+	var utilisationÉquipement UtilisationÉquipement
+
+	switch code % 10 {
+	// 1 – Oui
+	case 1:
+		utilisationÉquipement = Utilisé
+
+	// 2 – Non
+	case 2:
+		utilisationÉquipement = NonUtilisé
+
+	// 3 – Non déterminable
+	case 3:
+		utilisationÉquipement = UtilisationNonDéterminable
+
+	default:
+		return Équipements{}
+	}
+
+	équipements := Équipements{}
+
+	switch code / 10 {
+	// 1 – Ceinture
+	case 1:
+		équipements.Ceinture = utilisationÉquipement
+
+	// 2 – Casque
+	case 2:
+		équipements.Casque = utilisationÉquipement
+
+	// 3 – Dispositif enfants
+	case 3:
+		équipements.DispositifEnfants = utilisationÉquipement
+
+	// 4 – Equipement réfléchissant
+	case 4:
+		équipements.ÉquipementRéfléchissant = utilisationÉquipement
+
+	// 9 – Autre
+	case 9:
+		équipements.AutreÉquipement = utilisationÉquipement
+	}
+
+	return équipements
 }
 
 func parseFixedPointLatLong(latLongStr string) string {
@@ -2,27 +2,15 @@ package dataset
 
 import (
 	"fmt"
-	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 )
 
 type YearDatasetReader2 struct{}
 
-var delimiter2 = ';'
-
-func (*YearDatasetReader2) ReadCharacteristics(year uint, dataPath string) (accidents []*Accident, err error) {
-	var baseName string
-
-	if year >= 2023 {
-		baseName = "caract"
-	} else if year == 2021 || year == 2022 {
-		baseName = "carcteristiques"
-	} else {
-		baseName = "caracteristiques"
-	}
-
-	path := filepath.Join(dataPath, fmt.Sprint(year), fmt.Sprintf("%v-%v.csv", baseName, year))
+func (*YearDatasetReader2) ReadCharacteristics(year uint, emplacement Emplacement) (accidents []*Accident, err error) {
+	path := emplacement.String()
 
 	convertRow := func(row map[string]string) (*Accident, error) {
 		var idAccident string
@@ -142,22 +130,58 @@ func (*YearDatasetReader2) ReadCharacteristics(year uint, dataPath string) (acci
 			return nil, err
 		}
 
+		luminositéCode, err := readCode(row, "lum", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		agglomérationCode, err := readCode(row, "agg", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		intersectionCode, err := readCode(row, "int", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		conditionsAtmosphériquesCode, err := readCode(row, "atm", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		typeCollisionCode, err := readCode(row, "col", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
 		return &Accident{
-			IdAccident:  idAccident,
-			Date:        fmt.Sprintf("%04d-%02d-%02dT%v", anInt, moisInt, jourInt, heure),
-			Département: département,
-			Commune:     commune,
-			Adresse:     adresse,
-			Latitude:    strings.TrimRight(latitude, "0"),
-			Longitude:   strings.TrimRight(longitude, "0"),
+			IdAccident:               idAccident,
+			Date:                     fmt.Sprintf("%04d-%02d-%02dT%v", anInt, moisInt, jourInt, heure),
+			Département:              département,
+			Commune:                  commune,
+			Adresse:                  adresse,
+			Latitude:                 strings.TrimRight(latitude, "0"),
+			Longitude:                strings.TrimRight(longitude, "0"),
+			Luminosité:               parseLuminosité(luminositéCode),
+			Agglomération:            parseAgglomération(agglomérationCode),
+			Intersection:             parseIntersection(intersectionCode),
+			ConditionsAtmosphériques: parseConditionsAtmosphériques(conditionsAtmosphériquesCode),
+			TypeCollision:            parseTypeCollision(typeCollisionCode),
+			CodesOfficiels:           readCodesOfficiels(row, "lum", "agg", "int", "atm", "col"),
 		}, nil
 	}
 
-	return readCsvFile(path, delimiter2, convertRow)
+	return readCsvFile(emplacement, year, convertRow)
 }
 
-func (*YearDatasetReader2) ReadPlaces(year uint, dataPath string) (places []*Lieu, err error) {
-	path := filepath.Join(dataPath, fmt.Sprint(year), fmt.Sprintf("lieux-%v.csv", year))
+func (*YearDatasetReader2) ReadPlaces(year uint, emplacement Emplacement) (places []*Lieu, err error) {
+	path := emplacement.String()
 
 	convertRow := func(row map[string]string) (*Lieu, error) {
 		idAccident, err := readColumn(row, "Num_Acc", path)
@@ -215,17 +239,81 @@ func (*YearDatasetReader2) ReadPlaces(year uint, dataPath string) (places []*Lie
 			)
 		}
 
+		catégorieRouteCode, err := readCode(row, "catr", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		régimeCirculationCode, err := readCode(row, "circ", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		nombreVoies, err := readNombre(row, "nbv", path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		profilCode, err := readCode(row, "prof", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tracéEnPlanCode, err := readCode(row, "plan", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		étatSurfaceCode, err := readCode(row, "surf", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		aménagementCode, err := readCode(row, "infra", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		situationCode, err := readCode(row, "situ", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		vitesseMaximale, err := readNombre(row, "vma", path)
+
+		if err != nil {
+			return nil, err
+		}
+
 		return &Lieu{
-			IdAccident:   idAccident,
-			VoieSpéciale: voieSpéciale,
+			IdAccident:        idAccident,
+			CatégorieRoute:    parseCatégorieRoute(catégorieRouteCode),
+			RégimeCirculation: parseRégimeCirculation(régimeCirculationCode),
+			NombreVoies:       nombreVoies,
+			VoieSpéciale:      voieSpéciale,
+			Profil:            parseProfil(profilCode),
+			TracéEnPlan:       parseTracéEnPlan(tracéEnPlanCode),
+			ÉtatSurface:       parseÉtatSurface(étatSurfaceCode),
+			Aménagement:       parseAménagement(aménagementCode),
+			Situation:         parseSituation(situationCode),
+			VitesseMaximale:   vitesseMaximale,
+			CodesOfficiels:    readCodesOfficiels(row, "catr", "circ", "vosp", "prof", "plan", "surf", "infra", "situ"),
 		}, nil
 	}
 
-	return readCsvFile(path, delimiter2, convertRow)
+	return readCsvFile(emplacement, year, convertRow)
 }
 
-func (*YearDatasetReader2) ReadVehicles(year uint, dataPath string) (vehicles []*Véhicule, err error) {
-	path := filepath.Join(dataPath, fmt.Sprint(year), fmt.Sprintf("vehicules-%v.csv", year))
+func (*YearDatasetReader2) ReadVehicles(year uint, emplacement Emplacement) (vehicles []*Véhicule, err error) {
+	path := emplacement.String()
 
 	convertRow := func(row map[string]string) (*Véhicule, error) {
 		idAccident, err := readColumn(row, "Num_Acc", path)
@@ -240,6 +328,12 @@ func (*YearDatasetReader2) ReadVehicles(year uint, dataPath string) (vehicles []
 			return nil, err
 		}
 
+		numVéhicule, err := readColumn(row, "num_veh", path)
+
+		if err != nil {
+			return nil, err
+		}
+
 		catégorieVéhiculeStr, err := readColumn(row, "catv", path)
 
 		if err != nil {
@@ -315,22 +409,72 @@ func (*YearDatasetReader2) ReadVehicles(year uint, dataPath string) (vehicles []
 		case 40:
 			catégorieVéhicule = Tramway
 
+		// 50 – EDP à moteur
+		case 50:
+			catégorieVéhicule = EDPÀMoteur
+
+		// 60 – EDP sans moteur
+		case 60:
+			catégorieVéhicule = EDPSansMoteur
+
+		// 80 – VAE
+		case 80:
+			catégorieVéhicule = VéloÀAssistanceÉlectrique
+
 		default:
 			catégorieVéhicule = AutreVéhicule
 		}
 
+		sensCirculationCode, err := readCode(row, "senc", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		obstacleFixeCode, err := readCode(row, "obs", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		obstacleMobileCode, err := readCode(row, "obsm", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pointDeChocCode, err := readCode(row, "choc", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		manœuvreCode, err := readCode(row, "manv", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
 		return &Véhicule{
 			IdVéhicule:        idVéhicule,
+			NumVéhicule:       numVéhicule,
 			IdAccident:        idAccident,
 			CatégorieVéhicule: catégorieVéhicule,
+			SensCirculation:   parseSensCirculation(sensCirculationCode),
+			ObstacleFixe:      parseObstacleFixe(obstacleFixeCode),
+			ObstacleMobile:    parseObstacleMobile(obstacleMobileCode),
+			PointDeChoc:       parsePointDeChoc(pointDeChocCode),
+			Manœuvre:          parseManœuvre(manœuvreCode),
+			CodesOfficiels:    readCodesOfficiels(row, "catv", "senc", "obs", "obsm", "choc", "manv"),
 		}, nil
 	}
 
-	return readCsvFile(path, delimiter2, convertRow)
+	return readCsvFile(emplacement, year, convertRow)
 }
 
-func (*YearDatasetReader2) ReadUsers(year uint, dataPath string) (users []*Usager, err error) {
-	path := filepath.Join(dataPath, fmt.Sprint(year), fmt.Sprintf("usagers-%v.csv", year))
+func (*YearDatasetReader2) ReadUsers(year uint, emplacement Emplacement) (users []*Usager, err error) {
+	path := emplacement.String()
+	synthesiseIdUsager := makeIdUsagerSynthesiser()
 
 	convertRow := func(row map[string]string) (*Usager, error) {
 		idAccident, err := readColumn(row, "Num_Acc", path)
@@ -345,6 +489,24 @@ func (*YearDatasetReader2) ReadUsers(year uint, dataPath string) (users []*Usage
 			return nil, err
 		}
 
+		numVéhicule, err := readColumn(row, "num_veh", path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var idUsager string
+
+		if year >= 2021 {
+			idUsager, err = readColumn(row, "id_usager", path)
+
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			idUsager = synthesiseIdUsager(idAccident, numVéhicule)
+		}
+
 		catégorieUsagerStr, err := readColumn(row, "catu", path)
 
 		if err != nil {
@@ -481,15 +643,123 @@ func (*YearDatasetReader2) ReadUsers(year uint, dataPath string) (users []*Usage
 			}
 		}
 
+		var équipementsCodes []int
+
+		for _, columnName := range []string{"secu1", "secu2", "secu3"} {
+			équipementsCode, err := readCode(row, columnName, idAccident, path)
+
+			if err != nil {
+				return nil, err
+			}
+
+			équipementsCodes = append(équipementsCodes, équipementsCode)
+		}
+
+		motifTrajetCode, err := readCode(row, "trajet", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		place, err := readNombre(row, "place", path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		localisationPiétonCode, err := readCode(row, "locp", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		actionPiétonStr, err := readColumn(row, "actp", path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		étatPiétonCode, err := readCode(row, "etatp", idAccident, path)
+
+		if err != nil {
+			return nil, err
+		}
+
 		return &Usager{
-			IdVéhicule:      idVéhicule,
-			IdAccident:      idAccident,
-			CatégorieUsager: catégorieUsager,
-			Sexe:            sexe,
-			Gravité:         gravité,
-			AnnéeNaissance:  annéeNaissance,
+			IdUsager:           idUsager,
+			IdVéhicule:         idVéhicule,
+			NumVéhicule:        numVéhicule,
+			IdAccident:         idAccident,
+			CatégorieUsager:    catégorieUsager,
+			Sexe:               sexe,
+			Gravité:            gravité,
+			AnnéeNaissance:     annéeNaissance,
+			MotifTrajet:        parseMotifTrajet(motifTrajetCode),
+			Place:              parsePlace(place),
+			Équipements:        parseÉquipements2(équipementsCodes),
+			LocalisationPiéton: parseLocalisationPiéton(localisationPiétonCode),
+			ActionPiéton:       parseActionPiéton(actionPiétonStr),
+			ÉtatPiéton:         parseÉtatPiéton(étatPiétonCode),
+			CodesOfficiels:     readCodesOfficiels(row, "catu", "grav", "sexe", "trajet", "place", "locp", "actp", "etatp"),
 		}, nil
 	}
 
-	return readCsvFile(path, delimiter2, convertRow)
+	return readCsvFile(emplacement, year, convertRow)
+}
+
+// From 2019, the columns 'secu1', 'secu2' and 'secu3' list up to three types of
+// equipment that were used, so any type that is not listed was not used.
+func parseÉquipements2(codes []int) Équipements {
+	isSpecified := func(code int) bool { return code >= 0 && code != 8 }
+
+	if !slices.ContainsFunc(codes, isSpecified) {
+		if slices.Contains(codes, 8) {
+			// 8 – Non déterminable
+			return allÉquipements(UtilisationNonDéterminable)
+		} else {
+			// -1 – Non renseigné
+			return allÉquipements(UtilisationNonRenseignée)
+		}
+	}
+
+	équipements := allÉquipements(NonUtilisé)
+
+	for _, code := range codes {
+		switch code {
+		// 1 – Ceinture
+		case 1:
+			équipements.Ceinture = Utilisé
+
+		// 2 – Casque
+		case 2:
+			équipements.Casque = Utilisé
+
+		// 3 – Dispositif enfants
+		case 3:
+			équipements.DispositifEnfants = Utilisé
+
+		// 4 – Gilet réfléchissant
+		case 4:
+			équipements.ÉquipementRéfléchissant = Utilisé
+
+		// 5 – Airbag (2RM/3RM)
+		case 5:
+			équipements.Airbag = Utilisé
+
+		// 6 – Gants (2RM/3RM)
+		case 6:
+			équipements.Gants = Utilisé
+
+		// 7 – Gants + Airbag (2RM/3RM)
+		case 7:
+			équipements.Gants = Utilisé
+			équipements.Airbag = Utilisé
+
+		// 9 – Autre
+		case 9:
+			équipements.AutreÉquipement = Utilisé
+		}
+	}
+
+	return équipements
 }